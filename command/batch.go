@@ -0,0 +1,82 @@
+package command
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// "GO" is the batch separator of sql server, it is not the statement
+// ex. "GO" or "go 3", the batch before is repeated by the count
+var batchSeparator = regexp.MustCompile(`(?im)^[ \t]*GO(?:[ \t]+([0-9]+))?[ \t]*;?[ \t]*\r?$`)
+
+// pl/sql block needs the semicolon of "END;" or "END name;"
+var (
+	plsqlBlockStart = regexp.MustCompile(`(?i)^(?:DECLARE|BEGIN|CREATE[ \t\r\n]+(?:OR[ \t\r\n]+REPLACE[ \t\r\n]+)?(?:PROCEDURE|FUNCTION|PACKAGE|TRIGGER|TYPE[ \t\r\n]+BODY))\b`)
+	plsqlBlockEnd   = regexp.MustCompile(`(?i)\bEND(?:[ \t\r\n]+[A-Za-z0-9_$#"]+)?[ \t\r\n]*;$`)
+)
+
+// return the batches of sql run in order
+// sql is returned as it is if the engine has no batch rule
+func getBatches(engine string, sql string) []string {
+	dbEngine, err := getDBEngine(engine)
+	if err != nil || dbEngine.Batches == nil {
+		return []string{sql}
+	}
+
+	return dbEngine.Batches(sql)
+}
+
+// split by "GO" line of sql server
+func getSQLServerBatches(sql string) []string {
+	var batches []string
+	start := 0
+	for _, loc := range batchSeparator.FindAllStringSubmatchIndex(sql, -1) {
+		count := 1
+		if loc[2] >= 0 {
+			count, _ = strconv.Atoi(sql[loc[2]:loc[3]])
+		}
+		batches = appendBatch(batches, sql[start:loc[0]], count)
+		start = loc[1]
+	}
+	batches = appendBatch(batches, sql[start:], 1)
+
+	// sql has only separators
+	if len(batches) <= 0 {
+		return []string{sql}
+	}
+
+	return batches
+}
+
+// oracle runs one statement without the trailing semicolon
+func getOracleBatches(sql string) []string {
+	return []string{trimOracleStatement(sql)}
+}
+
+// oracle rejects the trailing semicolon of sql statement, and "/" of sql*plus
+// ex. "SELECT * FROM dual;" -> "SELECT * FROM dual"
+func trimOracleStatement(sql string) string {
+	sql = strings.TrimSpace(sql)
+	if strings.HasSuffix(sql, "\n/") {
+		sql = strings.TrimSpace(strings.TrimSuffix(sql, "/"))
+	}
+	if plsqlBlockStart.MatchString(sql) && plsqlBlockEnd.MatchString(sql) {
+		return sql
+	}
+
+	return strings.TrimSpace(strings.TrimRight(sql, ";"))
+}
+
+// empty batch is skipped
+func appendBatch(batches []string, batch string, count int) []string {
+	batch = strings.TrimSpace(batch)
+	if batch == "" {
+		return batches
+	}
+	for i := 0; i < count; i++ {
+		batches = append(batches, batch)
+	}
+
+	return batches
+}
@@ -0,0 +1,97 @@
+package command
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+)
+
+// resultChecksum struct is the sha256 hash of query result set variable
+// the column names and the values of each row are hashed in the returned order,
+// so the query needs "ORDER BY" to compare the results of two runs
+type resultChecksum struct {
+	hash hash.Hash
+}
+
+// the length of value is written before value, so ("ab", "c") and ("a", "bc") are different
+func newResultChecksum(cols []string) *resultChecksum {
+	r := &resultChecksum{hash: sha256.New()}
+	r.writeLength(len(cols))
+	for _, col := range cols {
+		r.writeValue([]byte(col))
+	}
+
+	return r
+}
+
+// NULL is distinguished from empty string
+func (r *resultChecksum) addRow(raw [][]byte) {
+	for _, value := range raw {
+		if value == nil {
+			r.hash.Write([]byte{0})
+			continue
+		}
+		r.hash.Write([]byte{1})
+		r.writeValue(value)
+	}
+}
+
+// return the hex string of sha256
+func (r *resultChecksum) sum() string {
+	return hex.EncodeToString(r.hash.Sum(nil))
+}
+
+func (r *resultChecksum) writeValue(value []byte) {
+	r.writeLength(len(value))
+	r.hash.Write(value)
+}
+
+func (r *resultChecksum) writeLength(length int) {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(length))
+	r.hash.Write(buf)
+}
+
+// countRowsResult struct is the Rows and Checksum of query result variable
+type countRowsResult struct {
+	Rows     int64
+	Checksum string
+}
+
+// count the rows and get the checksum without writing to file
+func countRows(rows *sql.Rows) (*countRowsResult, error) {
+	cols, err := rows.Columns()
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return nil, err
+	}
+
+	checksum := newResultChecksum(cols)
+	rawResult := make([][]byte, len(cols))
+	dest := make([]interface{}, len(cols))
+	for i := range rawResult {
+		dest[i] = &rawResult[i]
+	}
+
+	var count int64
+	for rows.Next() {
+		count++
+		if err := rows.Scan(dest...); err != nil {
+			log.Errorf("%s", err.Error())
+			return nil, err
+		}
+		checksum.addRow(rawResult)
+	}
+	// the rows are not read to the end
+	if err := rows.Err(); err != nil {
+		log.Errorf("%s", err.Error())
+		return nil, err
+	}
+
+	return &countRowsResult{
+		Rows:     count,
+		Checksum: checksum.sum(),
+	}, nil
+}
@@ -0,0 +1,363 @@
+package command
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/rds"
+
+	"github.com/uchimanajet7/rds-try/utils"
+)
+
+var (
+	// ErrDBClusterNotFound is the "DB Cluster is not found" error
+	ErrDBClusterNotFound = errors.New("DB Cluster is not found")
+	// ErrDBClusterWriterNotFound is the "DB Cluster writer instance is not found" error
+	ErrDBClusterWriterNotFound = errors.New("DB Cluster writer instance is not found")
+)
+
+// error codes of aws rds api when the resource does not exist
+const (
+	dbInstanceNotFoundCode = "DBInstanceNotFound"
+	dbClusterNotFoundCode  = "DBClusterNotFoundFault"
+)
+
+// check aws error code
+func isAWSErrorCode(err error, code string) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == code
+	}
+
+	return false
+}
+
+// IsDBClusterSource is check db_id is the identifier of Aurora DB Cluster
+// DB Cluster is looked up only when DB Instance of db_id does not exist
+func (c *Command) IsDBClusterSource(dbIdentifier string) (bool, error) {
+	_, err := c.DescribeDBInstance(dbIdentifier)
+	if err == nil {
+		return false, nil
+	}
+	if !isAWSErrorCode(err, dbInstanceNotFoundCode) && err != ErrDBInstancetNotFound {
+		return false, err
+	}
+
+	_, err = c.DescribeDBCluster(dbIdentifier)
+	if err != nil {
+		if isAWSErrorCode(err, dbClusterNotFoundCode) || err == ErrDBClusterNotFound {
+			return false, ErrDBInstancetNotFound
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *Command) describeDBClusters(input *rds.DescribeDBClustersInput) ([]*rds.DBCluster, error) {
+	output, err := c.RDSClient.DescribeDBClusters(input)
+
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return nil, err
+	}
+
+	return output.DBClusters, err
+}
+
+// DescribeDBCluster is show the aws rds db cluster infomations
+// all status in target, result return only one
+func (c *Command) DescribeDBCluster(clusterIdentifier string) (*rds.DBCluster, error) {
+	input := &rds.DescribeDBClustersInput{
+		DBClusterIdentifier: &clusterIdentifier,
+	}
+	output, err := c.describeDBClusters(input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dbLen := len(output)
+	if dbLen < 1 {
+		log.Errorf("%s", ErrDBClusterNotFound.Error())
+		return nil, ErrDBClusterNotFound
+	}
+
+	return output[dbLen-1], err
+}
+
+// DescribeDBClustersByTags is aws rds db clusters list up by tags
+func (c *Command) DescribeDBClustersByTags() ([]*rds.DBCluster, error) {
+	input := &rds.DescribeDBClustersInput{}
+
+	output, err := c.describeDBClusters(input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var dbClusters []*rds.DBCluster
+	for _, cluster := range output {
+		state, err := c.checkListTagsForResource(cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		if state {
+			dbClusters = append(dbClusters, cluster)
+		}
+	}
+
+	return dbClusters, err
+}
+
+// DescribeDBClusterWriter is show the writer db instance of aws rds db cluster
+func (c *Command) DescribeDBClusterWriter(cluster *rds.DBCluster) (*rds.DBInstance, error) {
+	for _, member := range cluster.DBClusterMembers {
+		if member.IsClusterWriter != nil && *member.IsClusterWriter {
+			return c.DescribeDBInstance(*member.DBInstanceIdentifier)
+		}
+	}
+
+	log.Errorf("%s", ErrDBClusterWriterNotFound.Error())
+	return nil, ErrDBClusterWriterNotFound
+}
+
+func (c *Command) describeDBClusterSnapshots(input *rds.DescribeDBClusterSnapshotsInput) ([]*rds.DBClusterSnapshot, error) {
+	output, err := c.RDSClient.DescribeDBClusterSnapshots(input)
+
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return nil, err
+	}
+
+	return output.DBClusterSnapshots, err
+}
+
+// DescribeLatestDBClusterSnapshot is show latest aws rds db cluster snap shot
+// the target only "available"
+func (c *Command) DescribeLatestDBClusterSnapshot(clusterIdentifier string) (*rds.DBClusterSnapshot, error) {
+	input := &rds.DescribeDBClusterSnapshotsInput{
+		DBClusterIdentifier: &clusterIdentifier,
+	}
+
+	output, err := c.describeDBClusterSnapshots(input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// want to filter by status "available"
+	var latest *rds.DBClusterSnapshot
+	for _, snapshot := range output {
+		if *snapshot.Status != "available" {
+			log.Debugf("DB Cluster Snapshot Status : %s", *snapshot.Status)
+			continue
+		}
+
+		if latest == nil || latest.SnapshotCreateTime == nil ||
+			(snapshot.SnapshotCreateTime != nil && snapshot.SnapshotCreateTime.After(*latest.SnapshotCreateTime)) {
+			latest = snapshot
+		}
+	}
+
+	if latest == nil {
+		log.Errorf("%s", ErrSnapshotNotFound.Error())
+		return nil, ErrSnapshotNotFound
+	}
+
+	return latest, err
+}
+
+// DescribeDBClusterSnapshot is show aws rds db cluster snap shot
+// all status in target, result return only one
+func (c *Command) DescribeDBClusterSnapshot(snapshotIdentifier string) (*rds.DBClusterSnapshot, error) {
+	input := &rds.DescribeDBClusterSnapshotsInput{
+		DBClusterSnapshotIdentifier: &snapshotIdentifier,
+	}
+
+	output, err := c.describeDBClusterSnapshots(input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dbLen := len(output)
+	if dbLen < 1 {
+		log.Errorf("%s", ErrSnapshotNotFound.Error())
+		return nil, ErrSnapshotNotFound
+	}
+
+	return output[dbLen-1], err
+}
+
+// DescribeDBClusterSnapshotsByTags is show aws rds db cluster snap shot by tags
+func (c *Command) DescribeDBClusterSnapshotsByTags() ([]*rds.DBClusterSnapshot, error) {
+	input := &rds.DescribeDBClusterSnapshotsInput{}
+
+	output, err := c.describeDBClusterSnapshots(input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var dbSnapshots []*rds.DBClusterSnapshot
+	for _, snapshot := range output {
+		state, err := c.checkListTagsForResource(snapshot)
+		if err != nil {
+			return nil, err
+		}
+
+		if state {
+			dbSnapshots = append(dbSnapshots, snapshot)
+		}
+	}
+
+	return dbSnapshots, err
+}
+
+// CreateDBClusterSnapshot is create aws rds db cluster snap shot
+func (c *Command) CreateDBClusterSnapshot(clusterIdentifier string) (*rds.DBClusterSnapshot, error) {
+	snapshotID := utils.GetFormatedDBDisplayName(clusterIdentifier)
+	input := &rds.CreateDBClusterSnapshotInput{
+		DBClusterIdentifier:         &clusterIdentifier,
+		DBClusterSnapshotIdentifier: &snapshotID,
+		Tags:                        c.getSpecifyTags(), // It must always be set to not forget
+	}
+
+	output, err := c.RDSClient.CreateDBClusterSnapshot(input)
+
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return nil, err
+	}
+
+	return output.DBClusterSnapshot, err
+}
+
+// RestoreDBClusterFromSnapshotArgs struct is the DBIdentifier and Snapshot and Cluster and SubnetGroup and SecurityGroups and EnableIAMAuth variable
+type RestoreDBClusterFromSnapshotArgs struct {
+	DBIdentifier   string
+	Snapshot       *rds.DBClusterSnapshot
+	Cluster        *rds.DBCluster // copy the settings from this cluster
+	SubnetGroup    string         // used rather than Cluster if not empty
+	SecurityGroups []string       // used rather than Cluster if not empty
+	EnableIAMAuth  bool           // iam database authentication is the setting of cluster
+}
+
+// RestoreDBClusterFromSnapshot is restore aws rds db cluster from db cluster snap shot
+// the cluster has no db instance, so create the writer by CreateDBClusterInstance
+func (c *Command) RestoreDBClusterFromSnapshot(args *RestoreDBClusterFromSnapshotArgs) (*rds.DBCluster, error) {
+	input := &rds.RestoreDBClusterFromSnapshotInput{
+		DBClusterIdentifier: &args.DBIdentifier,
+		SnapshotIdentifier:  args.Snapshot.DBClusterSnapshotIdentifier,
+		Engine:              args.Snapshot.Engine,
+		EngineVersion:       args.Snapshot.EngineVersion,
+		Tags:                append(c.getSpecifyTags(), getSnapshotTag(args.Snapshot.DBClusterSnapshotIdentifier)), // It must always be set to not forget
+	}
+	// default subnet group and security group are used if nil
+	if args.Cluster != nil {
+		input.DBSubnetGroupName = args.Cluster.DBSubnetGroup
+		input.DBClusterParameterGroupName = args.Cluster.DBClusterParameterGroup
+		for _, item := range args.Cluster.VpcSecurityGroups {
+			input.VpcSecurityGroupIds = append(input.VpcSecurityGroupIds, item.VpcSecurityGroupId)
+		}
+	}
+	if args.SubnetGroup != "" {
+		input.DBSubnetGroupName = &args.SubnetGroup
+	}
+	if args.EnableIAMAuth {
+		input.EnableIAMDatabaseAuthentication = &args.EnableIAMAuth
+	}
+	if len(args.SecurityGroups) > 0 {
+		input.VpcSecurityGroupIds = nil
+		for i := range args.SecurityGroups {
+			input.VpcSecurityGroupIds = append(input.VpcSecurityGroupIds, &args.SecurityGroups[i])
+		}
+	}
+
+	output, err := c.RDSClient.RestoreDBClusterFromSnapshot(input)
+
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return nil, err
+	}
+
+	return output.DBCluster, err
+}
+
+// CreateDBClusterInstanceArgs struct is the DBIdentifier and DBInstanceClass and Cluster and ParameterGroup variable
+type CreateDBClusterInstanceArgs struct {
+	DBIdentifier    string
+	DBInstanceClass string
+	Cluster         *rds.DBCluster
+	ParameterGroup  string // default db parameter group is used if empty
+}
+
+// CreateDBClusterInstance is create aws rds db instance in the db cluster
+// the first db instance of the cluster becomes the writer
+func (c *Command) CreateDBClusterInstance(args *CreateDBClusterInstanceArgs) (*rds.DBInstance, error) {
+	input := &rds.CreateDBInstanceInput{
+		DBInstanceIdentifier: &args.DBIdentifier,
+		DBInstanceClass:      &args.DBInstanceClass,
+		DBClusterIdentifier:  args.Cluster.DBClusterIdentifier,
+		Engine:               args.Cluster.Engine,
+		Tags:                 c.getSpecifyTags(), // It must always be set to not forget
+	}
+	if args.ParameterGroup != "" {
+		input.DBParameterGroupName = &args.ParameterGroup
+	}
+
+	output, err := c.RDSClient.CreateDBInstance(input)
+
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return nil, err
+	}
+
+	return output.DBInstance, err
+}
+
+// DeleteDBCluster is delete aws rds db cluster
+// delete DB cluster and skip create snapshot
+// the db instances of the cluster must be deleted before
+func (c *Command) DeleteDBCluster(clusterIdentifier string) (*rds.DBCluster, error) {
+	skip := true
+	input := &rds.DeleteDBClusterInput{
+		DBClusterIdentifier: &clusterIdentifier,
+		SkipFinalSnapshot:   &skip, // "SkipFinalSnapshot" is always true
+	}
+
+	output, err := c.RDSClient.DeleteDBCluster(input)
+
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return nil, err
+	}
+
+	return output.DBCluster, err
+}
+
+// DeleteDBClusterSnapshot is delete aws rds db cluster snap shot
+func (c *Command) DeleteDBClusterSnapshot(snapshotIdentifier string) (*rds.DBClusterSnapshot, error) {
+	input := &rds.DeleteDBClusterSnapshotInput{
+		DBClusterSnapshotIdentifier: &snapshotIdentifier,
+	}
+
+	output, err := c.RDSClient.DeleteDBClusterSnapshot(input)
+
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return nil, err
+	}
+
+	return output.DBClusterSnapshot, err
+}
+
+// return the endpoint of the db cluster
+// the queries are executed to this endpoint, not to the writer instance
+func getDBClusterEndpoint(cluster *rds.DBCluster) *rds.Endpoint {
+	return &rds.Endpoint{
+		Address: cluster.Endpoint,
+		Port:    cluster.Port,
+	}
+}
@@ -1,245 +1,1723 @@
-package command
-
-import (
-	"errors"
-	"flag"
-	"fmt"
-
-	"github.com/aws/aws-sdk-go/service/rds"
-
-	"github.com/uchimanajet7/rds-try/query"
-	"github.com/uchimanajet7/rds-try/utils"
-)
-
-// EsCommand struct is the *Command and OptQuery and OptType and OptSnap variable
-type EsCommand struct {
-	*Command
-	OptQuery string
-	OptType  string
-	OptSnap  bool
-}
-
-// ErrDBInstancetTimeOut is the "DB Instance is time out" error
-var ErrDBInstancetTimeOut = errors.New("DB Instance is time out")
-
-// Help is the show help text
-func (c *EsCommand) Help() string {
-	// to-do: removal of the fixed value
-	helpText := fmt.Sprintf("\nUsage: %s es [options]\n\n", utils.GetAppName())
-	helpText += "Options:\n"
-	helpText += "  -q, --query  specify an alternate query file\n"
-	helpText += "  -s, --snap   create snapshot before restore\n"
-	helpText += "  -t, --type   specify an alternate db instance class\n"
-
-	return helpText
-}
-
-// Synopsis is the show short help text
-func (c *EsCommand) Synopsis() string {
-	// why ES ?
-	// execute and store = ES
-	return "restore db and get results by execute sql"
-}
-
-// Run is the start command
-func (c *EsCommand) Run(args []string) int {
-	log.Infof("start command : es")
-
-	// reset flag
-	fs := flag.NewFlagSet("es", flag.ExitOnError)
-
-	// register flag name
-	fs.StringVar(&c.OptQuery, "query", "", "specify an alternate query file")
-	fs.StringVar(&c.OptQuery, "q", "", "specify an alternate query file")
-	fs.StringVar(&c.OptType, "type", "", "specify an alternate db instance class")
-	fs.StringVar(&c.OptType, "t", "", "specify an alternate db instance class")
-	fs.BoolVar(&c.OptSnap, "snap", false, "create snapshot before restore")
-	fs.BoolVar(&c.OptSnap, "s", false, "create snapshot before restore")
-
-	fs.Usage = func() { fmt.Println(c.Help()) }
-	err := fs.Parse(args)
-	if err != nil {
-		log.Errorf("%s", err.Error())
-		return 1
-	}
-
-	err = c.runDetails(fs)
-	if err != nil {
-		log.Errorf("%s", err.Error())
-		return 1
-	}
-
-	log.Infof("end command : es")
-
-	return 0
-}
-
-func (c *EsCommand) runDetails(f *flag.FlagSet) error {
-	// load query
-	queryFile := query.GetDefaultPath()
-	if c.OptQuery != "" {
-		queryFile = c.OptQuery
-	}
-	queries, err := query.LoadQuery(queryFile)
-	if err != nil {
-		return err
-	}
-	log.Debugf("%+v", queries)
-
-	// option create snapshot
-	// or
-	// get latest db snap shot
-	var snapShot *rds.DBSnapshot
-	if c.OptSnap {
-		snapShot, err = c.CreateDBSnapshot(c.RDSConfig.DBId)
-		if err != nil {
-			return err
-		}
-
-		// wait for available
-		waitChan := c.WaitForStatusAvailable(snapShot)
-		if !<-waitChan {
-			return ErrDBInstancetTimeOut
-		}
-	} else {
-		snapShot, err = c.DescribeLatestDBSnapshot(c.RDSConfig.DBId)
-		if err != nil {
-			return err
-		}
-	}
-
-	// get now active db info
-	// to-do: can not run if the running instance does not exist
-	actDB, err := c.DescribeDBInstance(c.RDSConfig.DBId)
-	if err != nil {
-		return err
-	}
-
-	// "DBInstanceClass" is determined in the following order
-	// 1. argument value
-	// 2. config file type
-	// 3. running DB Instance Class
-	restType := *actDB.DBInstanceClass
-	if c.RDSConfig.Type != "" {
-		restType = c.OptType
-	}
-	if c.OptType != "" {
-		restType = c.OptType
-	}
-	restName := utils.GetFormatedDBDisplayName(c.RDSConfig.DBId)
-	restArgs := &RestoreDBInstanceFromDBSnapshotArgs{
-		DBInstanceClass: restType,
-		DBIdentifier:    restName,
-		MultiAZ:         c.RDSConfig.MultiAz,
-		Snapshot:        snapShot,
-		Instance:        actDB,
-	}
-	restDB, err := c.RestoreDBInstanceFromDBSnapshot(restArgs)
-	if err != nil {
-		return err
-	}
-	log.Infof("%+v", *restArgs)
-
-	// wait for available
-	waitChan := c.WaitForStatusAvailable(restDB)
-	if !<-waitChan {
-		return ErrDBInstancetTimeOut
-	}
-
-	// DB is restored in the default state
-	// So, I do modify
-	restDB, err = c.ModifyDBInstance(restName, actDB)
-	if err != nil {
-		return err
-	}
-
-	// wait for available
-	waitChan = c.WaitForStatusAvailable(restDB)
-	if !<-waitChan {
-		return ErrDBInstancetTimeOut
-	}
-
-	// enable the setting by performing reboot
-	restDB, err = c.RebootDBInstance(restName)
-	if err != nil {
-		return err
-	}
-
-	// wait for available
-	waitChan = c.WaitForStatusAvailable(restDB)
-	if !<-waitChan {
-		return ErrDBInstancetTimeOut
-	}
-
-	// get db info
-	restDB, err = c.DescribeDBInstance(restName)
-	if err != nil {
-		return err
-	}
-
-	// setting check
-	var count = 1
-	for c.CheckPendingStatus(restDB) {
-		// max count
-		if count > 6 {
-			return ErrDBInstancetTimeOut
-		}
-
-		count++
-		log.Infof("restart %d times! because change has not been applied", count)
-
-		// once again reboot
-		restDB, err = c.RebootDBInstance(restName)
-		if err != nil {
-			return err
-		}
-
-		// wait for available
-		waitChan = c.WaitForStatusAvailable(restDB)
-		if !<-waitChan {
-			return ErrDBInstancetTimeOut
-		}
-
-		// get db info
-		restDB, err = c.DescribeDBInstance(restName)
-		if err != nil {
-			return err
-		}
-	}
-
-	// run queries
-	times, err := c.ExecuteSQL(
-		&ExecuteSQLArgs{
-			Engine:   *restDB.Engine,
-			Endpoint: restDB.Endpoint,
-			Queries:  queries.Query,
-		})
-	if err != nil {
-		return err
-	}
-
-	// show total time
-	var total float64
-	totalText := "\nruntime result:\n"
-	for i, time := range times {
-		total += time.Seconds()
-		totalText += fmt.Sprintf("  query name   : %s\n  query runtime: %s\n\n", queries.Query[i].Name, time.String())
-	}
-
-	hour := int(total) / 3600
-	minute := (int(total) - hour*3600) / 60
-	second := total - float64(hour*3600) - float64(minute*60)
-
-	totalText += "--------------------------------\n"
-	timeText := fmt.Sprintf("  total runtime: %.3f sec\n", second)
-	if minute > 0 {
-		timeText = fmt.Sprintf("  total runtime: %d m %.3f sec\n", minute, second)
-	}
-	if hour > 0 {
-		timeText = fmt.Sprintf("  total runtime: %d h %d m %.3f sec\n", hour, minute, second)
-	}
-	totalText += timeText
-	fmt.Println(totalText)
-
-	return nil
-}
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/rds"
+
+	"github.com/uchimanajet7/rds-try/history"
+	"github.com/uchimanajet7/rds-try/notify"
+	"github.com/uchimanajet7/rds-try/price"
+	"github.com/uchimanajet7/rds-try/query"
+	"github.com/uchimanajet7/rds-try/utils"
+)
+
+// EsCommand struct is the *Command and OptQuery and OptType and OptSnap and Storage options and Upgrade options and OptMigration and OptCopy and OptTTL and OptReport and OptReplica and created resources and progress variable
+type EsCommand struct {
+	*Command
+	OptQuery            string
+	OptType             string
+	OptSnap             bool
+	OptStorageType      string
+	OptIops             int64
+	OptAllocatedStorage int64
+	OptUpgrade          string
+	OptUpgradeGroup     string
+	OptCompare          bool
+	OptMigration        string
+	OptCopy             bool
+	OptTTL              string
+	OptReport           string
+	OptReplica          bool
+	runs                []esRun // the query runs written to history
+	interrupt           chan struct{}
+	created             []createdResource
+	createdMutex        sync.Mutex
+	progress            *progress
+}
+
+// createdResource struct is the command of region and db instance or snap shot variable
+type createdResource struct {
+	command  *Command
+	resource interface{}
+}
+
+// action when es is interrupted, ask if not specified
+const (
+	interruptDelete = "delete"
+	interruptKeep   = "keep"
+)
+
+var (
+	// ErrDBInstancetTimeOut is the "DB Instance is time out" error
+	ErrDBInstancetTimeOut = errors.New("DB Instance is time out")
+	// ErrCompareWithoutUpgrade is the "compare option requires upgrade option" error
+	ErrCompareWithoutUpgrade = errors.New("compare option requires upgrade option")
+	// ErrSnapWithSharedSnapshot is the "snap option can not be used with shared snapshot" error
+	ErrSnapWithSharedSnapshot = errors.New("snap option can not be used with shared snapshot")
+	// ErrDBInstanceClassNotFound is the "DB Instance Class is not found" error
+	ErrDBInstanceClassNotFound = errors.New("DB Instance Class is not found")
+	// ErrInterruptedEs is the "OS Interrupted es" error
+	ErrInterruptedEs = errors.New("OS Interrupted es")
+	// ErrKmsKeyNotFound is the "KMS Key is required to copy encrypted snapshot" error
+	ErrKmsKeyNotFound = errors.New("KMS Key is required to copy encrypted snapshot")
+	// ErrClusterOptionNotSupported is the "option is not supported with DB Cluster" error
+	ErrClusterOptionNotSupported = errors.New("option is not supported with DB Cluster")
+	// ErrReplicaOptionNotSupported is the "option is not supported with read replica" error
+	ErrReplicaOptionNotSupported = errors.New("option is not supported with read replica")
+	// ErrIopsNotFound is the "iops is required with io1 storage type" error
+	ErrIopsNotFound = errors.New("iops is required with io1 storage type")
+	// ErrIopsNotSupported is the "iops is supported only with io1 storage type" error
+	ErrIopsNotSupported = errors.New("iops is supported only with io1 storage type")
+	// ErrSharedNetworkNotFound is the "subnet_group and security_groups are required with shared snapshot" error
+	ErrSharedNetworkNotFound = errors.New("subnet_group and security_groups are required with shared snapshot")
+)
+
+// Help is the show help text
+func (c *EsCommand) Help() string {
+	// to-do: removal of the fixed value
+	helpText := fmt.Sprintf("\nUsage: %s es [options]\n\n", utils.GetAppName())
+	helpText += "Options:\n"
+	helpText += "  -q, --query              specify an alternate query file\n"
+	helpText += "  -s, --snap               create snapshot before restore\n"
+	helpText += "  -t, --type               specify an alternate db instance class\n"
+	helpText += "      --storage-type       specify an alternate storage type (standard, gp2, io1)\n"
+	helpText += "      --iops               specify an alternate provisioned iops\n"
+	helpText += "      --allocated-storage  specify an alternate allocated storage (GB)\n"
+	helpText += "      --upgrade            upgrade to engine version before execute sql\n"
+	helpText += "      --upgrade-group      specify db parameter group of upgraded engine version\n"
+	helpText += "      --compare            execute sql on not upgraded db for comparison\n"
+	helpText += "  -m, --migration          apply migration file before execute sql\n"
+	helpText += "      --copy               copy snapshot to copy_region and restore there\n"
+	helpText += "      --ttl                set expiry time of created resources (e.g. 6h)\n"
+	helpText += "      --report             write run report file in format (md, html)\n"
+	helpText += "      --replica            create read replica instead of restore from snapshot\n"
+
+	return helpText
+}
+
+// Synopsis is the show short help text
+func (c *EsCommand) Synopsis() string {
+	// why ES ?
+	// execute and store = ES
+	return "restore db and get results by execute sql"
+}
+
+// Run is the start command
+func (c *EsCommand) Run(args []string) int {
+	log.Infof("start command : es")
+
+	// reset flag
+	fs := flag.NewFlagSet("es", flag.ExitOnError)
+
+	// register flag name
+	fs.StringVar(&c.OptQuery, "query", "", "specify an alternate query file")
+	fs.StringVar(&c.OptQuery, "q", "", "specify an alternate query file")
+	fs.StringVar(&c.OptType, "type", "", "specify an alternate db instance class")
+	fs.StringVar(&c.OptType, "t", "", "specify an alternate db instance class")
+	fs.BoolVar(&c.OptSnap, "snap", false, "create snapshot before restore")
+	fs.BoolVar(&c.OptSnap, "s", false, "create snapshot before restore")
+	fs.StringVar(&c.OptStorageType, "storage-type", "", "specify an alternate storage type (standard, gp2, io1)")
+	fs.Int64Var(&c.OptIops, "iops", 0, "specify an alternate provisioned iops")
+	fs.Int64Var(&c.OptAllocatedStorage, "allocated-storage", 0, "specify an alternate allocated storage (GB)")
+	fs.StringVar(&c.OptUpgrade, "upgrade", "", "upgrade to engine version before execute sql")
+	fs.StringVar(&c.OptUpgradeGroup, "upgrade-group", "", "specify db parameter group of upgraded engine version")
+	fs.BoolVar(&c.OptCompare, "compare", false, "execute sql on not upgraded db for comparison")
+	fs.StringVar(&c.OptMigration, "migration", "", "apply migration file before execute sql")
+	fs.StringVar(&c.OptMigration, "m", "", "apply migration file before execute sql")
+	fs.BoolVar(&c.OptCopy, "copy", false, "copy snapshot to copy_region and restore there")
+	fs.StringVar(&c.OptTTL, "ttl", "", "set expiry time of created resources (e.g. 6h)")
+	fs.StringVar(&c.OptReport, "report", "", "write run report file in format (md, html)")
+	fs.BoolVar(&c.OptReplica, "replica", false, "create read replica instead of restore from snapshot")
+
+	fs.Usage = func() { fmt.Println(c.Help()) }
+	err := fs.Parse(args)
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return 1
+	}
+
+	// SIGINT and SIGTERM stop waiting
+	// the region commands are kept before switched to "copy_region"
+	sTime := time.Now()
+	commands := c.GetRegionCommands()
+	stopTrap := c.trapSignals()
+	c.startProgress()
+	err = c.runDetails(fs)
+	c.progress.stop()
+	stopTrap()
+	// the sql stopped by the signal returns the error of its own
+	if err != nil && c.isInterrupted() {
+		err = ErrInterruptedEs
+	}
+	remains := c.created
+	if err == ErrInterruptedEs {
+		remains = c.cleanupCreatedResources()
+	} else if err == nil {
+		c.enforceRetention(commands)
+	}
+	c.sendNotify(err, remains)
+	c.writeHistory(err, time.Now().Sub(sTime))
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return 1
+	}
+
+	log.Infof("end command : es")
+
+	return 0
+}
+
+func (c *EsCommand) runDetails(f *flag.FlagSet) error {
+	// the comparison copy is meaningless without upgrade
+	if c.OptCompare && c.OptUpgrade == "" {
+		return ErrCompareWithoutUpgrade
+	}
+	if c.OptCopy && c.CopyCommand == nil {
+		return ErrCopyRegionNotFound
+	}
+	// the source db instance of shared snapshot is in other AWS account
+	shared := c.RDSConfig.SnapshotType == SharedSnapshotType
+	if shared && c.OptSnap {
+		return ErrSnapWithSharedSnapshot
+	}
+	// the network settings of source db instance are not able to be used
+	if shared && (c.RDSConfig.SubnetGroup == "" || len(c.RDSConfig.SecurityGroups) <= 0) {
+		return ErrSharedNetworkNotFound
+	}
+	if c.OptReport != "" && !isReportFormat(c.OptReport) {
+		return ErrReportFormatNotFound
+	}
+	// checked before restore, the connection is made after that
+	if err := c.CheckSSLMode(); err != nil {
+		return err
+	}
+	if err := c.CheckIAMAuth(); err != nil {
+		return err
+	}
+	// read replica is created from running db instance and is read only
+	if c.OptReplica && (shared || c.OptSnap || c.OptCopy || c.OptUpgrade != "" || c.OptCompare ||
+		c.OptMigration != "" || c.RDSConfig.KmsKeyID != "") {
+		return ErrReplicaOptionNotSupported
+	}
+	// the storage settings of running db instance are checked again after described
+	if err := c.checkStorageSettings(nil); err != nil {
+		return err
+	}
+
+	// "TTL" is determined in the following order
+	// 1. argument value
+	// 2. config file ttl
+	ttlText := c.RDSConfig.TTL
+	if c.OptTTL != "" {
+		ttlText = c.OptTTL
+	}
+	if ttlText != "" {
+		ttl, err := time.ParseDuration(ttlText)
+		if err != nil {
+			log.Errorf("%s", err.Error())
+			return err
+		}
+		c.TTL = ttl
+		if c.CopyCommand != nil {
+			c.CopyCommand.TTL = ttl
+		}
+	}
+
+	// load query
+	queryFile := query.GetDefaultPath()
+	if c.OptQuery != "" {
+		queryFile = c.OptQuery
+	}
+	queries, err := query.LoadQuery(queryFile)
+	if err != nil {
+		return err
+	}
+	log.Debugf("%+v", queries)
+
+	// option load migration
+	var migrations []query.Query
+	if c.OptMigration != "" {
+		migration, err := query.LoadMigration(c.OptMigration)
+		if err != nil {
+			return err
+		}
+		migrations = migration.Migration
+	}
+
+	// masking rules of config file and query file
+	// the rules are checked here not to restore with wrong rules
+	masks, err := getMaskQueries(append(append([]query.Mask{}, c.RDSConfig.Mask...), queries.Mask...))
+	if err != nil {
+		return err
+	}
+
+	// load price file for cost estimation
+	prices, err := loadPrices()
+	if err != nil {
+		return err
+	}
+
+	// read replica of running db instance
+	// read replica is read only, so masking is not able to be applied
+	if c.OptReplica {
+		if len(masks) > 0 {
+			return ErrReplicaOptionNotSupported
+		}
+		return c.runReplicaDetails(queries.Query, prices)
+	}
+
+	// Aurora DB Cluster is restored from the cluster snapshot
+	if !shared {
+		cluster, err := c.IsDBClusterSource(c.RDSConfig.DBId)
+		if err != nil {
+			return err
+		}
+		if cluster {
+			return c.runClusterDetails(queries.Query, migrations, masks, prices)
+		}
+	}
+
+	// get now active db info
+	// shared snapshot has no running instance in this account
+	var actDB *rds.DBInstance
+	if !shared {
+		actDB, err = c.DescribeDBInstance(c.RDSConfig.DBId)
+		if err != nil {
+			return err
+		}
+		// checked before the snapshot is created
+		if err := c.checkDBEngine(actDB.Engine, len(masks) > 0); err != nil {
+			return err
+		}
+		if err := c.checkStorageSettings(actDB); err != nil {
+			return err
+		}
+	}
+
+	// option create snapshot
+	// or
+	// get latest db snap shot
+	var snapShot *rds.DBSnapshot
+	if c.OptSnap {
+		if err := c.startPhase(c.progress, "create snapshot"); err != nil {
+			return err
+		}
+		snapShot, err = c.CreateDBSnapshot(c.RDSConfig.DBId)
+		if err != nil {
+			return err
+		}
+		c.addCreatedResource(c.Command, snapShot)
+
+		// wait for available
+		if err := c.waitForStatusAvailable(c.Command, snapShot); err != nil {
+			return err
+		}
+	} else if shared {
+		snapShot, err = c.describeSharedDBSnapshot()
+		if err != nil {
+			return err
+		}
+		if err := c.checkDBEngine(snapShot.Engine, len(masks) > 0); err != nil {
+			return err
+		}
+		actDB = getSharedDBInstance(snapShot)
+		if err := c.checkStorageSettings(actDB); err != nil {
+			return err
+		}
+	} else {
+		snapShot, err = c.DescribeLatestDBSnapshot(c.RDSConfig.DBId)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the copied snapshot is new, so keep the source for the report
+	sourceID := c.RDSConfig.DBId
+	if snapShot.DBInstanceIdentifier != nil {
+		sourceID = *snapShot.DBInstanceIdentifier
+	}
+	snapTime := snapShot.SnapshotCreateTime
+
+	// option copy snapshot to "copy_region"
+	// or
+	// copy snapshot to re-encrypt by "kms_key_id"
+	if c.OptCopy {
+		// KMS Key is specific to the region
+		if isEncryptedDBSnapshot(snapShot) && c.RDSConfig.KmsKeyID == "" {
+			return ErrKmsKeyNotFound
+		}
+
+		snapShot, err = c.copyDBSnapshot(snapShot, c.CopyCommand)
+		if err != nil {
+			return err
+		}
+
+		// after this, restore and execute sql are performed in "copy_region"
+		c.Command = c.CopyCommand
+	} else if c.RDSConfig.KmsKeyID != "" {
+		snapShot, err = c.copyDBSnapshot(snapShot, c.Command)
+		if err != nil {
+			return err
+		}
+	}
+
+	// "DBInstanceClass" is determined in the following order
+	// 1. argument value
+	// 2. config file type
+	// 3. running DB Instance Class
+	var restType string
+	if actDB.DBInstanceClass != nil {
+		restType = *actDB.DBInstanceClass
+	}
+	if c.RDSConfig.Type != "" {
+		restType = c.RDSConfig.Type
+	}
+	if c.OptType != "" {
+		restType = c.OptType
+	}
+	if restType == "" {
+		return ErrDBInstanceClassNotFound
+	}
+	storage := c.getStorageSettings(actDB, snapShot)
+	restArgs := &RestoreDBInstanceFromDBSnapshotArgs{
+		DBInstanceClass: restType,
+		DBIdentifier:    utils.GetFormatedDBDisplayName(c.RDSConfig.DBId),
+		MultiAZ:         c.RDSConfig.MultiAz,
+		StorageType:     storage.StorageType,
+		Iops:            storage.Iops,
+		Snapshot:        snapShot,
+		Instance:        c.getSettingsDBInstance(actDB),
+	}
+
+	// the lifetime of restored db instance is measured from here
+	restoreTime := time.Now()
+
+	// restore the comparison copy at the same time
+	var baseDB *rds.DBInstance
+	var baseErr error
+	baseChan := make(chan bool, 1)
+	if c.OptCompare {
+		baseArgs := *restArgs
+		baseArgs.DBIdentifier = utils.GetFormatedDBDisplayName(c.RDSConfig.DBId + "-base")
+		// the status of comparison copy is not shown on the phase line
+		baseCommand := *c.Command
+		baseCommand.OnStatus = nil
+		go func() {
+			baseDB, _, baseErr = c.setupDBInstance(&baseCommand, &baseArgs, storage, nil)
+			baseChan <- true
+		}()
+	} else {
+		baseChan <- true
+	}
+
+	restDB, modified, err := c.setupDBInstance(c.Command, restArgs, storage, c.progress)
+	<-baseChan
+	if err != nil {
+		return err
+	}
+	if baseErr != nil {
+		return baseErr
+	}
+
+	summary := &esSummary{
+		EnvName:          c.Name,
+		Region:           c.RDSConfig.Region,
+		SourceID:         sourceID,
+		SnapshotID:       *snapShot.DBSnapshotIdentifier,
+		SnapshotTime:     snapTime,
+		DBInstanceClass:  restType,
+		Storage:          storage,
+		ModifiedSettings: modified,
+	}
+	// encryption is inherited from the snapshot
+	if restDB.StorageEncrypted != nil {
+		summary.Encrypted = *restDB.StorageEncrypted
+	}
+	if restDB.KmsKeyId != nil {
+		summary.KmsKeyID = *restDB.KmsKeyId
+	}
+
+	// option engine version upgrade
+	if c.OptUpgrade != "" {
+		summary.Upgrade = &upgradeResult{
+			FromVersion: *restDB.EngineVersion,
+			ToVersion:   c.OptUpgrade,
+		}
+
+		if err := c.startPhase(c.progress, "upgrade to "+c.OptUpgrade); err != nil {
+			return err
+		}
+		sTime := time.Now()
+		restDB, err = c.upgradeDBInstance(*restDB.DBInstanceIdentifier, c.OptUpgrade)
+		if err != nil {
+			return err
+		}
+		summary.Upgrade.Time = time.Now().Sub(sTime)
+		log.Infof("upgrade runtime: %s", summary.Upgrade.Time.String())
+	}
+
+	// run queries
+	run, err := c.executeRun(restDB, queries.Query, migrations, masks, c.progress)
+	if err != nil {
+		return err
+	}
+	summary.Runs = append(summary.Runs, *run)
+
+	// run queries on the comparison copy
+	if baseDB != nil {
+		run, err = c.executeRun(baseDB, queries.Query, migrations, masks, c.progress)
+		if err != nil {
+			return err
+		}
+		summary.Runs = append(summary.Runs, *run)
+	}
+
+	// option cost estimate of restored db instances
+	if prices != nil {
+		summary.Cost = c.estimateCost(prices, []*rds.DBInstance{restDB, baseDB}, time.Now().Sub(restoreTime))
+	}
+
+	return c.showSummary(summary)
+}
+
+// restore Aurora DB Cluster from the cluster snapshot and create the writer db instance
+// the queries are executed to the cluster endpoint
+func (c *EsCommand) runClusterDetails(queries []query.Query, migrations []query.Query, masks []query.Query, prices *price.Prices) error {
+	// settings of db instance storage, engine version and snapshot copy are not supported
+	if c.OptCopy || c.OptUpgrade != "" || c.OptCompare || c.RDSConfig.KmsKeyID != "" ||
+		c.OptStorageType != "" || c.OptIops > 0 || c.OptAllocatedStorage > 0 {
+		return ErrClusterOptionNotSupported
+	}
+
+	actCluster, err := c.DescribeDBCluster(c.RDSConfig.DBId)
+	if err != nil {
+		return err
+	}
+	if err := c.checkDBEngine(actCluster.Engine, len(masks) > 0); err != nil {
+		return err
+	}
+
+	// option create cluster snapshot
+	// or
+	// get latest db cluster snap shot
+	var snapShot *rds.DBClusterSnapshot
+	if c.OptSnap {
+		if err := c.startPhase(c.progress, "create snapshot"); err != nil {
+			return err
+		}
+		snapShot, err = c.CreateDBClusterSnapshot(c.RDSConfig.DBId)
+		if err != nil {
+			return err
+		}
+		c.addCreatedResource(c.Command, snapShot)
+
+		// wait for available
+		if err := c.waitForStatusAvailable(c.Command, snapShot); err != nil {
+			return err
+		}
+	} else {
+		snapShot, err = c.DescribeLatestDBClusterSnapshot(c.RDSConfig.DBId)
+		if err != nil {
+			return err
+		}
+	}
+
+	// "DBInstanceClass" is determined in the following order
+	// 1. argument value
+	// 2. config file type
+	// 3. running writer DB Instance Class
+	restType := c.RDSConfig.Type
+	if c.OptType != "" {
+		restType = c.OptType
+	}
+	if restType == "" {
+		writer, err := c.DescribeDBClusterWriter(actCluster)
+		if err != nil {
+			return err
+		}
+		restType = *writer.DBInstanceClass
+	}
+
+	// the lifetime of restored db instance is measured from here
+	restoreTime := time.Now()
+	restArgs := &RestoreDBClusterFromSnapshotArgs{
+		DBIdentifier:   utils.GetFormatedDBDisplayName(c.RDSConfig.DBId),
+		Snapshot:       snapShot,
+		Cluster:        actCluster,
+		SubnetGroup:    c.RDSConfig.SubnetGroup,
+		SecurityGroups: c.RDSConfig.SecurityGroups,
+		EnableIAMAuth:  c.RDSConfig.IAMAuth,
+	}
+	restCluster, restDB, err := c.setupDBCluster(restArgs, restType, c.progress)
+	if err != nil {
+		return err
+	}
+
+	summary := &esSummary{
+		EnvName:         c.Name,
+		Region:          c.RDSConfig.Region,
+		SourceID:        c.RDSConfig.DBId,
+		DBClusterID:     *restCluster.DBClusterIdentifier,
+		SnapshotID:      *snapShot.DBClusterSnapshotIdentifier,
+		SnapshotTime:    snapShot.SnapshotCreateTime,
+		DBInstanceClass: restType,
+	}
+	// encryption is inherited from the snapshot
+	if restCluster.StorageEncrypted != nil {
+		summary.Encrypted = *restCluster.StorageEncrypted
+	}
+	if restCluster.KmsKeyId != nil {
+		summary.KmsKeyID = *restCluster.KmsKeyId
+	}
+
+	// run queries
+	run, err := c.executeRun(restDB, queries, migrations, masks, c.progress)
+	if err != nil {
+		return err
+	}
+	summary.Runs = append(summary.Runs, *run)
+
+	// option cost estimate of the writer db instance
+	if prices != nil {
+		summary.Cost = c.estimateCost(prices, []*rds.DBInstance{restDB}, time.Now().Sub(restoreTime))
+	}
+
+	return c.showSummary(summary)
+}
+
+// create read replica of running db instance
+// the queries are executed to the replica with the latest data, no snapshot is used
+func (c *EsCommand) runReplicaDetails(queries []query.Query, prices *price.Prices) error {
+	actDB, err := c.DescribeDBInstance(c.RDSConfig.DBId)
+	if err != nil {
+		return err
+	}
+	if err := c.checkDBEngine(actDB.Engine, false); err != nil {
+		return err
+	}
+	if err := c.checkStorageSettings(actDB); err != nil {
+		return err
+	}
+
+	// "DBInstanceClass" is determined in the following order
+	// 1. argument value
+	// 2. config file type
+	// 3. running DB Instance Class
+	var restType string
+	if actDB.DBInstanceClass != nil {
+		restType = *actDB.DBInstanceClass
+	}
+	if c.RDSConfig.Type != "" {
+		restType = c.RDSConfig.Type
+	}
+	if c.OptType != "" {
+		restType = c.OptType
+	}
+	if restType == "" {
+		return ErrDBInstanceClassNotFound
+	}
+	storage := c.getStorageSettings(actDB, nil)
+	replicaArgs := &CreateDBInstanceReadReplicaArgs{
+		DBInstanceClass: restType,
+		DBIdentifier:    utils.GetFormatedDBDisplayName(c.RDSConfig.DBId),
+		MultiAZ:         c.RDSConfig.MultiAz,
+		StorageType:     storage.StorageType,
+		Iops:            storage.Iops,
+		Instance:        c.getSettingsDBInstance(actDB),
+	}
+
+	// the lifetime of replica db instance is measured from here
+	restoreTime := time.Now()
+	restDB, modified, err := c.setupReadReplica(replicaArgs, storage, c.progress)
+	if err != nil {
+		return err
+	}
+
+	summary := &esSummary{
+		EnvName:          c.Name,
+		Region:           c.RDSConfig.Region,
+		SourceID:         c.RDSConfig.DBId,
+		Replica:          true,
+		DBInstanceClass:  restType,
+		Storage:          storage,
+		ModifiedSettings: modified,
+	}
+	// encryption is inherited from the source db instance
+	if restDB.StorageEncrypted != nil {
+		summary.Encrypted = *restDB.StorageEncrypted
+	}
+	if restDB.KmsKeyId != nil {
+		summary.KmsKeyID = *restDB.KmsKeyId
+	}
+
+	// run queries
+	run, err := c.executeRun(restDB, queries, nil, nil, c.progress)
+	if err != nil {
+		return err
+	}
+	summary.Runs = append(summary.Runs, *run)
+
+	// option cost estimate of replica db instance
+	if prices != nil {
+		summary.Cost = c.estimateCost(prices, []*rds.DBInstance{restDB}, time.Now().Sub(restoreTime))
+	}
+
+	return c.showSummary(summary)
+}
+
+// show summary and write report file
+func (c *EsCommand) showSummary(summary *esSummary) error {
+	c.progress.stop()
+	summary.Phases = c.progress.getPhases()
+	c.runs = summary.Runs
+	fmt.Println(summary.getText())
+
+	// option write report file
+	if c.OptReport != "" {
+		reportFile, err := c.writeReportFile(summary, c.OptReport)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("report file: %s\n", reportFile)
+	}
+
+	return nil
+}
+
+// trap SIGINT and SIGTERM, and close "Interrupt" of commands
+// return function to stop trapping
+func (c *EsCommand) trapSignals() func() {
+	c.interrupt = make(chan struct{})
+	c.Interrupt = c.interrupt
+	if c.CopyCommand != nil {
+		c.CopyCommand.Interrupt = c.interrupt
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case sig := <-sigCh:
+			// Print a newline so that any further output starts properly
+			// on a new line.
+			fmt.Println("")
+			log.Infof("signal received: %s", sig)
+			close(c.interrupt)
+
+			// the next signal is handled by default, and exits without cleanup
+			signal.Stop(sigCh)
+			fmt.Println("interrupted: stopping at the next step, press Ctrl-C again to exit immediately")
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
+
+// show the phases of es and latest status while waiting
+func (c *EsCommand) startProgress() {
+	c.progress = newProgress(os.Stdout)
+	c.OnStatus = c.progress.setStatus
+	if c.CopyCommand != nil {
+		c.CopyCommand.OnStatus = c.progress.setStatus
+	}
+}
+
+// check es is interrupted
+func (c *EsCommand) isInterrupted() bool {
+	select {
+	case <-c.interrupt:
+		return true
+	default:
+		return false
+	}
+}
+
+// check es is interrupted before the phase calling AWS, and start the phase
+// the phases are shown by p, nil if not shown
+func (c *EsCommand) startPhase(p *progress, phase string) error {
+	if c.isInterrupted() {
+		return ErrInterruptedEs
+	}
+	p.setPhase(phase)
+
+	return nil
+}
+
+// wait for available by the command of the region
+func (c *EsCommand) waitForStatusAvailable(command *Command, rdstypes interface{}) error {
+	if <-command.WaitForStatusAvailable(rdstypes) {
+		return nil
+	}
+	if c.isInterrupted() {
+		return ErrInterruptedEs
+	}
+
+	return ErrDBInstancetTimeOut
+}
+
+// record db instance or snap shot created by this run
+// it is called from the goroutine of comparison copy too
+func (c *EsCommand) addCreatedResource(command *Command, rdstypes interface{}) {
+	c.createdMutex.Lock()
+	defer c.createdMutex.Unlock()
+
+	c.created = append(c.created, createdResource{command: command, resource: rdstypes})
+}
+
+// the action is determined in the following order
+// 1. config file on_interrupt "delete" or "keep"
+// 2. answer of question
+// return the resources remaining
+func (c *EsCommand) cleanupCreatedResources() []createdResource {
+	if len(c.created) <= 0 {
+		return nil
+	}
+
+	action := c.RDSConfig.OnInterrupt
+	if action != interruptDelete && action != interruptKeep {
+		action = interruptKeep
+		fmt.Printf("\nlist of db resources created by this run\n")
+		for i, item := range c.created {
+			fmt.Printf("  [% d] %s\n", i+1, getCreatedResourceText(item))
+		}
+		fmt.Println("")
+
+		askResp, err := askQuestion("you want to delete all of those? [y/n]:")
+		if err != nil {
+			log.Errorf("%s", err.Error())
+		}
+		// blank new line
+		fmt.Println("")
+
+		switch askResp {
+		case "y", "Y", "yes", "YES", "Yes":
+			action = interruptDelete
+		}
+	}
+
+	// resources failed to delete remain
+	// deleted in reverse order of creation, the db instances of cluster are deleted before the cluster
+	var remains []createdResource
+	if action == interruptDelete {
+		for i := len(c.created) - 1; i >= 0; i-- {
+			item := c.created[i]
+			var err error
+			switch rdstype := item.resource.(type) {
+			case *rds.DBSnapshot:
+				_, err = item.command.DeleteDBSnapshot(*rdstype.DBSnapshotIdentifier)
+			case *rds.DBInstance:
+				_, err = item.command.DeleteDBInstance(*rdstype.DBInstanceIdentifier)
+			case *rds.DBCluster:
+				_, err = item.command.DeleteDBCluster(*rdstype.DBClusterIdentifier)
+			case *rds.DBClusterSnapshot:
+				_, err = item.command.DeleteDBClusterSnapshot(*rdstype.DBClusterSnapshotIdentifier)
+			}
+			if err != nil {
+				remains = append(remains, item)
+				continue
+			}
+			log.Infof("deleted %s", getCreatedResourceText(item))
+		}
+	} else {
+		remains = c.created
+	}
+
+	if len(remains) <= 0 {
+		return nil
+	}
+	fmt.Printf("\nlist of db resources remaining\n")
+	for i, item := range remains {
+		fmt.Printf("  [% d] %s\n", i+1, getCreatedResourceText(item))
+	}
+	fmt.Printf("\nplease delete them by rm command\n\n")
+
+	return remains
+}
+
+// return display text of created resource
+// ex. "DB Instance: rds-try-v0-0-1-2015-02-25-10-37-12-test-db in ap-northeast-1"
+func getCreatedResourceText(item createdResource) string {
+	switch rdstype := item.resource.(type) {
+	case *rds.DBSnapshot:
+		return fmt.Sprintf("DB Snapshot: %s in %s", *rdstype.DBSnapshotIdentifier, item.command.RDSConfig.Region)
+	case *rds.DBInstance:
+		return fmt.Sprintf("DB Instance: %s in %s", *rdstype.DBInstanceIdentifier, item.command.RDSConfig.Region)
+	case *rds.DBCluster:
+		return fmt.Sprintf("DB Cluster: %s in %s", *rdstype.DBClusterIdentifier, item.command.RDSConfig.Region)
+	case *rds.DBClusterSnapshot:
+		return fmt.Sprintf("DB Cluster Snapshot: %s in %s", *rdstype.DBClusterSnapshotIdentifier, item.command.RDSConfig.Region)
+	}
+
+	return ""
+}
+
+// delete snapshots over the retention policy of the environment after es succeeded
+// the snapshots created by this run are kept
+// the failure is shown, but the result of es is not changed
+func (c *EsCommand) enforceRetention(commands []*Command) {
+	excludes := c.getCreatedSnapshotIDs()
+	for _, command := range commands {
+		if !command.HasRetention() {
+			continue
+		}
+
+		snapList, clusterSnapList, err := command.DescribeOverRetentionSnapshots(excludes)
+		if err != nil {
+			log.Errorf("retention policy is not enforced in %s: %s", command.RDSConfig.Region, err.Error())
+			continue
+		}
+
+		var deleted, failed []string
+		for _, snap := range snapList {
+			text := getCreatedResourceText(createdResource{command: command, resource: snap})
+			if _, err := command.DeleteDBSnapshot(*snap.DBSnapshotIdentifier); err != nil {
+				failed = append(failed, text)
+				continue
+			}
+			deleted = append(deleted, text)
+		}
+		for _, snap := range clusterSnapList {
+			text := getCreatedResourceText(createdResource{command: command, resource: snap})
+			if _, err := command.DeleteDBClusterSnapshot(*snap.DBClusterSnapshotIdentifier); err != nil {
+				failed = append(failed, text)
+				continue
+			}
+			deleted = append(deleted, text)
+		}
+
+		if len(deleted) > 0 {
+			fmt.Println("\nlist of snapshot deleted by retention policy")
+			for i, item := range deleted {
+				fmt.Printf("  [% d] %s\n", i+1, item)
+			}
+		}
+		if len(failed) > 0 {
+			log.Errorf("failed to delete %d snapshots by retention policy", len(failed))
+			fmt.Println("\nlist of snapshot failed to delete by retention policy")
+			for i, item := range failed {
+				fmt.Printf("  [% d] %s\n", i+1, item)
+			}
+		}
+	}
+}
+
+// return the identifiers of db snapshots and db cluster snapshots created by this run
+func (c *EsCommand) getCreatedSnapshotIDs() []string {
+	c.createdMutex.Lock()
+	defer c.createdMutex.Unlock()
+
+	var ids []string
+	for _, item := range c.created {
+		switch rdstype := item.resource.(type) {
+		case *rds.DBSnapshot:
+			ids = append(ids, *rdstype.DBSnapshotIdentifier)
+		case *rds.DBClusterSnapshot:
+			ids = append(ids, *rdstype.DBClusterSnapshotIdentifier)
+		}
+	}
+
+	return ids
+}
+
+// notify the result of es with the remaining created resources
+// the failure of notification does not change the result of es
+func (c *EsCommand) sendNotify(err error, remains []createdResource) {
+	event := &notify.Event{
+		Command: "es",
+		Status:  notify.StatusSucceeded,
+		Name:    c.Name,
+		Time:    time.Now(),
+	}
+	switch err {
+	case nil:
+	case ErrDBInstancetTimeOut:
+		event.Status = notify.StatusTimeout
+		event.Message = err.Error()
+	default:
+		event.Status = notify.StatusFailed
+		event.Message = err.Error()
+	}
+	for _, item := range remains {
+		event.Resources = append(event.Resources, getCreatedResourceText(item))
+	}
+
+	c.Notifier.Send(event)
+}
+
+// write the result of es to history file
+// the failure of history does not change the result of es
+func (c *EsCommand) writeHistory(err error, elapsed time.Duration) {
+	if c.HistoryFile == "" {
+		return
+	}
+
+	entry := &history.Entry{
+		Command: "es",
+		Name:    c.Name,
+		Status:  history.StatusSucceeded,
+		Elapsed: elapsed,
+	}
+	switch err {
+	case nil:
+	case ErrDBInstancetTimeOut:
+		entry.Status = history.StatusTimeout
+		entry.Message = err.Error()
+	default:
+		entry.Status = history.StatusFailed
+		entry.Message = err.Error()
+	}
+	entry.Queries = getHistoryQueries(c.runs)
+
+	history.Append(c.HistoryFile, entry)
+}
+
+// the rows and checksum of each query are written to history
+func getHistoryQueries(runs []esRun) []history.Query {
+	var queries []history.Query
+	for _, run := range runs {
+		for i := range run.Times {
+			q := history.Query{
+				DBIdentifier: run.DBIdentifier,
+				Name:         run.Queries[i].Name,
+			}
+			if i < len(run.Rows) {
+				q.Rows = run.Rows[i]
+			}
+			if i < len(run.Checksums) {
+				q.Checksum = run.Checksums[i]
+			}
+			queries = append(queries, q)
+		}
+	}
+
+	return queries
+}
+
+// shared snapshot is specified by "snapshot_arn"
+// if not specified, latest shared snapshot of "db_id" is used
+// encrypted shared snapshot is restored after copied by the KMS Key of this account, so "kms_key_id" is checked here
+func (c *EsCommand) describeSharedDBSnapshot() (*rds.DBSnapshot, error) {
+	var snapShot *rds.DBSnapshot
+	var err error
+	if c.RDSConfig.SnapshotARN != "" {
+		snapShot, err = c.DescribeSharedDBSnapshot(c.RDSConfig.SnapshotARN)
+	} else {
+		snapShot, err = c.DescribeLatestSharedDBSnapshot(c.RDSConfig.DBId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if isEncryptedDBSnapshot(snapShot) && c.RDSConfig.KmsKeyID == "" {
+		log.Errorf("%s: %s", ErrKmsKeyNotFound.Error(), *snapShot.DBSnapshotArn)
+		return nil, ErrKmsKeyNotFound
+	}
+
+	return snapShot, nil
+}
+
+func isEncryptedDBSnapshot(snapShot *rds.DBSnapshot) bool {
+	return snapShot.Encrypted != nil && *snapShot.Encrypted
+}
+
+// running DB Instance of shared snapshot does not exist in this account
+// so, only the settings held by the snapshot are used
+// and the network settings must be specified in config file
+func getSharedDBInstance(snapShot *rds.DBSnapshot) *rds.DBInstance {
+	return &rds.DBInstance{
+		DBInstanceIdentifier: snapShot.DBInstanceIdentifier,
+		Engine:               snapShot.Engine,
+		EngineVersion:        snapShot.EngineVersion,
+		StorageType:          snapShot.StorageType,
+		Iops:                 snapShot.Iops,
+		AllocatedStorage:     snapShot.AllocatedStorage,
+	}
+}
+
+// estimate the cost of restored db instances running for lifetime
+// return nil if the price of any db instance is not found
+func (c *EsCommand) estimateCost(prices *price.Prices, dbList []*rds.DBInstance, lifetime time.Duration) *costResult {
+	result := &costResult{
+		Lifetime: lifetime,
+	}
+	for _, db := range dbList {
+		if db == nil {
+			continue
+		}
+
+		cost, err := prices.Estimate(getEstimateArgs(db, c.RDSConfig.Region, lifetime))
+		if err != nil {
+			log.Infof("skip cost estimate: %s", err.Error())
+			return nil
+		}
+		result.Cost += cost
+	}
+
+	return result
+}
+
+// copy db snapshot by the command of target region and wait for available
+// re-encrypted by "kms_key_id" if specified
+func (c *EsCommand) copyDBSnapshot(snapShot *rds.DBSnapshot, target *Command) (*rds.DBSnapshot, error) {
+	// db snapshot of other region or account is specified by ARN
+	copyArgs := &CopyDBSnapshotArgs{
+		SourceSnapshotIdentifier: c.getARNString(snapShot),
+		DBIdentifier:             c.RDSConfig.DBId,
+		KmsKeyID:                 target.RDSConfig.KmsKeyID,
+	}
+	if target.RDSConfig.Region != c.RDSConfig.Region {
+		copyArgs.SourceRegion = c.RDSConfig.Region
+	}
+	if err := c.startPhase(c.progress, "copy snapshot to "+target.RDSConfig.Region); err != nil {
+		return nil, err
+	}
+	copySnap, err := target.CopyDBSnapshot(copyArgs)
+	if err != nil {
+		return nil, err
+	}
+	c.addCreatedResource(target, copySnap)
+	log.Infof("copy DB Snapshot: %s to %s", copyArgs.SourceSnapshotIdentifier, target.RDSConfig.Region)
+
+	// wait for available
+	if err := c.waitForStatusAvailable(target, copySnap); err != nil {
+		return nil, err
+	}
+
+	return target.DescribeDBSnapshot(*copySnap.DBSnapshotIdentifier)
+}
+
+// the settings of restored db instance are determined in the following order
+// 1. config file value
+// 2. running DB Instance value
+// the network settings of running DB Instance are not used in "copy_region"
+func (c *EsCommand) getSettingsDBInstance(actDB *rds.DBInstance) *rds.DBInstance {
+	setDB := *actDB
+	if c.OptCopy {
+		setDB.DBSubnetGroup = nil
+		setDB.DBParameterGroups = nil
+		setDB.VpcSecurityGroups = nil
+	}
+
+	if c.RDSConfig.SubnetGroup != "" {
+		setDB.DBSubnetGroup = &rds.DBSubnetGroup{
+			DBSubnetGroupName: &c.RDSConfig.SubnetGroup,
+		}
+	}
+	if c.RDSConfig.ParameterGroup != "" {
+		setDB.DBParameterGroups = []*rds.DBParameterGroupStatus{
+			{DBParameterGroupName: &c.RDSConfig.ParameterGroup},
+		}
+	}
+	if len(c.RDSConfig.SecurityGroups) > 0 {
+		setDB.VpcSecurityGroups = nil
+		for i := range c.RDSConfig.SecurityGroups {
+			setDB.VpcSecurityGroups = append(setDB.VpcSecurityGroups, &rds.VpcSecurityGroupMembership{
+				VpcSecurityGroupId: &c.RDSConfig.SecurityGroups[i],
+			})
+		}
+	}
+	log.Debugf("settings DB Instance: %+v", setDB)
+
+	return &setDB
+}
+
+// apply migrations and masking and execute queries on the restored db instance
+// the queries are not run if masking failed, not to read the unmasked data
+// the phases are shown by p, nil if not shown
+func (c *EsCommand) executeRun(restDB *rds.DBInstance, queries []query.Query, migrations []query.Query, masks []query.Query, p *progress) (*esRun, error) {
+	run := &esRun{
+		DBIdentifier:  *restDB.DBInstanceIdentifier,
+		EngineVersion: *restDB.EngineVersion,
+		Queries:       queries,
+		Migrations:    migrations,
+		Masks:         masks,
+	}
+
+	// option apply migration
+	if len(migrations) > 0 {
+		times, err := c.ExecuteMigration(
+			&ExecuteSQLArgs{
+				Engine:   *restDB.Engine,
+				Endpoint: restDB.Endpoint,
+				Queries:  migrations,
+				OnStart: func(i int) {
+					p.setPhase(fmt.Sprintf("migration %d of %d", i+1, len(migrations)))
+				},
+			})
+		run.MigrationTimes = times
+		if err != nil {
+			// show the applied statements and the failed statement
+			p.stop()
+			fmt.Println(run.getMigrationText(err))
+			return nil, err
+		}
+	}
+
+	// option apply masking after migration, before queries
+	if len(masks) > 0 {
+		times, err := c.ExecuteMigration(
+			&ExecuteSQLArgs{
+				Engine:   *restDB.Engine,
+				Endpoint: restDB.Endpoint,
+				Queries:  masks,
+				OnStart: func(i int) {
+					p.setPhase(fmt.Sprintf("masking %d of %d", i+1, len(masks)))
+				},
+			})
+		run.MaskTimes = times
+		run.MaskErr = err
+		if err != nil {
+			// show the applied rules and the failed rule
+			p.stop()
+			fmt.Println(run.getMaskText())
+			log.Errorf("%s", err.Error())
+			return nil, ErrMaskFailed
+		}
+	}
+
+	result, err := c.ExecuteSQL(
+		&ExecuteSQLArgs{
+			Engine:   *restDB.Engine,
+			Endpoint: restDB.Endpoint,
+			Queries:  queries,
+			OnStart: func(i int) {
+				p.setPhase(fmt.Sprintf("query %d of %d", i+1, len(queries)))
+			},
+		})
+	if err != nil {
+		return nil, err
+	}
+	run.Times = result.Times
+	run.Rows = result.Rows
+	run.Checksums = result.Checksums
+	run.Files = result.Files
+
+	return run, nil
+}
+
+// restore db cluster and create the writer db instance
+// the endpoint of returned writer is replaced by the cluster endpoint
+// the phases are shown by p, nil if not shown
+func (c *EsCommand) setupDBCluster(restArgs *RestoreDBClusterFromSnapshotArgs, restType string, p *progress) (*rds.DBCluster, *rds.DBInstance, error) {
+	if err := c.startPhase(p, "restore cluster"); err != nil {
+		return nil, nil, err
+	}
+	restCluster, err := c.RestoreDBClusterFromSnapshot(restArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.addCreatedResource(c.Command, restCluster)
+
+	// wait for available
+	if err := c.waitForStatusAvailable(c.Command, restCluster); err != nil {
+		return nil, nil, err
+	}
+
+	// restored cluster has no db instance
+	if err := c.startPhase(p, "create writer"); err != nil {
+		return nil, nil, err
+	}
+	restDB, err := c.CreateDBClusterInstance(
+		&CreateDBClusterInstanceArgs{
+			DBIdentifier:    restArgs.DBIdentifier + "-writer",
+			DBInstanceClass: restType,
+			Cluster:         restCluster,
+			ParameterGroup:  c.RDSConfig.ParameterGroup,
+		})
+	if err != nil {
+		return nil, nil, err
+	}
+	c.addCreatedResource(c.Command, restDB)
+
+	// wait for available
+	if err := c.waitForStatusAvailable(c.Command, restDB); err != nil {
+		return nil, nil, err
+	}
+
+	// get cluster and writer info
+	restCluster, err = c.DescribeDBCluster(restArgs.DBIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	restDB, err = c.DescribeDBInstance(*restDB.DBInstanceIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	restDB.Endpoint = getDBClusterEndpoint(restCluster)
+
+	return restCluster, restDB, nil
+}
+
+// restore db instance and apply the settings of running db instance
+// return the names of the modified settings
+// the phases are shown by p, nil if not shown
+func (c *EsCommand) setupDBInstance(command *Command, restArgs *RestoreDBInstanceFromDBSnapshotArgs, storage storageSettings, p *progress) (*rds.DBInstance, []string, error) {
+	restName := restArgs.DBIdentifier
+	if err := c.startPhase(p, "restore"); err != nil {
+		return nil, nil, err
+	}
+	restDB, err := command.RestoreDBInstanceFromDBSnapshot(restArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.addCreatedResource(command, restDB)
+	log.Infof("%+v", *restArgs)
+
+	// wait for available
+	if err := c.waitForStatusAvailable(command, restDB); err != nil {
+		return nil, nil, err
+	}
+
+	return c.applyRunningSettings(command, restName, restArgs.Instance, storage, p)
+}
+
+// create read replica and apply the settings of running db instance
+func (c *EsCommand) setupReadReplica(replicaArgs *CreateDBInstanceReadReplicaArgs, storage storageSettings, p *progress) (*rds.DBInstance, []string, error) {
+	if err := c.startPhase(p, "create replica"); err != nil {
+		return nil, nil, err
+	}
+	restDB, err := c.CreateDBInstanceReadReplica(replicaArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.addCreatedResource(c.Command, restDB)
+	log.Infof("%+v", *replicaArgs)
+
+	// wait for available
+	if err := c.waitForStatusAvailable(c.Command, restDB); err != nil {
+		return nil, nil, err
+	}
+
+	return c.applyRunningSettings(c.Command, replicaArgs.DBIdentifier, replicaArgs.Instance, storage, p)
+}
+
+// modify and reboot the restored db instance to the settings of running db instance
+func (c *EsCommand) applyRunningSettings(command *Command, restName string, instance *rds.DBInstance, storage storageSettings, p *progress) (*rds.DBInstance, []string, error) {
+	// get db info
+	restDB, err := command.DescribeDBInstance(restName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// DB is restored in the default state
+	// So, I do modify only the settings that differ from running DB
+	// "AllocatedStorage" can not be specified at the time of restore
+	modified := c.GetModifiedSettings(restDB, instance, storage.AllocatedStorage)
+	if len(modified) <= 0 {
+		log.Infof("skip modify and reboot: settings are same as %s", *instance.DBInstanceIdentifier)
+		return restDB, modified, nil
+	}
+	log.Infof("modify settings: %s", strings.Join(modified, ", "))
+
+	modifyArgs := &ModifyDBInstanceArgs{
+		DBIdentifier:  restName,
+		Instance:      instance,
+		EnableIAMAuth: c.RDSConfig.IAMAuth,
+	}
+	if restDB.AllocatedStorage == nil || storage.AllocatedStorage != *restDB.AllocatedStorage {
+		modifyArgs.AllocatedStorage = storage.AllocatedStorage
+	}
+	if err := c.startPhase(p, "modify"); err != nil {
+		return nil, nil, err
+	}
+	restDB, err = command.ModifyDBInstance(modifyArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// wait for available
+	if err := c.waitForStatusAvailable(command, restDB); err != nil {
+		return nil, nil, err
+	}
+
+	// get db info
+	restDB, err = command.DescribeDBInstance(restName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// security groups and storage are applied without reboot
+	if !c.CheckPendingReboot(restDB) {
+		log.Infof("skip reboot: parameter group is in-sync")
+		return restDB, modified, nil
+	}
+
+	if err := c.startPhase(p, "reboot"); err != nil {
+		return nil, nil, err
+	}
+	restDB, err = c.rebootDBInstance(command, restName)
+	return restDB, modified, err
+}
+
+// upgrade db instance engine version
+// major version upgrade is always allowed
+func (c *EsCommand) upgradeDBInstance(restName string, engineVersion string) (*rds.DBInstance, error) {
+	log.Infof("upgrade engine version: %s", engineVersion)
+
+	modifyArgs := &ModifyDBInstanceArgs{
+		DBIdentifier:             restName,
+		EngineVersion:            engineVersion,
+		AllowMajorVersionUpgrade: true,
+		DBParameterGroupName:     c.OptUpgradeGroup,
+	}
+	restDB, err := c.ModifyDBInstance(modifyArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	// wait for available
+	if err := c.waitForStatusAvailable(c.Command, restDB); err != nil {
+		return nil, err
+	}
+
+	// get db info
+	restDB, err = c.DescribeDBInstance(restName)
+	if err != nil {
+		return nil, err
+	}
+
+	// the parameter group of new version needs reboot
+	if c.CheckPendingReboot(restDB) {
+		if err := c.startPhase(c.progress, "reboot"); err != nil {
+			return nil, err
+		}
+		return c.rebootDBInstance(c.Command, restName)
+	}
+
+	return restDB, nil
+}
+
+// enable the setting by performing reboot
+func (c *EsCommand) rebootDBInstance(command *Command, restName string) (*rds.DBInstance, error) {
+	restDB, err := command.RebootDBInstance(restName)
+	if err != nil {
+		return nil, err
+	}
+
+	// wait for available
+	if err := c.waitForStatusAvailable(command, restDB); err != nil {
+		return nil, err
+	}
+
+	// get db info
+	restDB, err = command.DescribeDBInstance(restName)
+	if err != nil {
+		return nil, err
+	}
+
+	// setting check
+	var count = 1
+	for c.CheckPendingStatus(restDB) {
+		// max count
+		if count > 6 {
+			return nil, ErrDBInstancetTimeOut
+		}
+
+		count++
+		log.Infof("restart %d times! because change has not been applied", count)
+
+		// once again reboot
+		restDB, err = command.RebootDBInstance(restName)
+		if err != nil {
+			return nil, err
+		}
+
+		// wait for available
+		if err := c.waitForStatusAvailable(command, restDB); err != nil {
+			return nil, err
+		}
+
+		// get db info
+		restDB, err = command.DescribeDBInstance(restName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return restDB, nil
+}
+
+// storageSettings struct is the StorageType and Iops and AllocatedStorage variable
+type storageSettings struct {
+	StorageType      string
+	Iops             int64
+	AllocatedStorage int64
+}
+
+// storage settings are determined in the following order
+// 1. argument value
+// 2. config file value
+// 3. running DB Instance or DB Snapshot value
+func (c *EsCommand) getStorageSettings(actDB *rds.DBInstance, snapShot *rds.DBSnapshot) storageSettings {
+	var storage storageSettings
+	if actDB.StorageType != nil {
+		storage.StorageType = *actDB.StorageType
+	}
+	if actDB.Iops != nil {
+		storage.Iops = *actDB.Iops
+	}
+	// read replica has no snapshot, so the same as running db instance
+	if snapShot == nil {
+		if actDB.AllocatedStorage != nil {
+			storage.AllocatedStorage = *actDB.AllocatedStorage
+		}
+	} else if snapShot.AllocatedStorage != nil {
+		storage.AllocatedStorage = *snapShot.AllocatedStorage
+	}
+
+	if c.RDSConfig.StorageType != "" {
+		storage.StorageType = c.RDSConfig.StorageType
+	}
+	if c.OptStorageType != "" {
+		storage.StorageType = c.OptStorageType
+	}
+
+	if c.RDSConfig.Iops > 0 {
+		storage.Iops = c.RDSConfig.Iops
+	}
+	if c.OptIops > 0 {
+		storage.Iops = c.OptIops
+	}
+	// provisioned iops is available only "io1"
+	if storage.StorageType != "io1" {
+		storage.Iops = 0
+	}
+
+	if c.RDSConfig.AllocatedStorage > 0 {
+		storage.AllocatedStorage = c.RDSConfig.AllocatedStorage
+	}
+	if c.OptAllocatedStorage > 0 {
+		storage.AllocatedStorage = c.OptAllocatedStorage
+	}
+	log.Debugf("storage settings: %+v", storage)
+
+	return storage
+}
+
+// provisioned iops is available only "io1", and "io1" needs iops
+// actDB is nil if the running db instance is not described, then only the specified settings are checked
+func (c *EsCommand) checkStorageSettings(actDB *rds.DBInstance) error {
+	srcDB := actDB
+	if srcDB == nil {
+		srcDB = &rds.DBInstance{}
+	}
+	storage := c.getStorageSettings(srcDB, nil)
+
+	// the iops of running db instance is not used with other storage type
+	if storage.StorageType != "" && storage.StorageType != "io1" && (c.RDSConfig.Iops > 0 || c.OptIops > 0) {
+		log.Errorf("%s: %s", ErrIopsNotSupported.Error(), storage.StorageType)
+		return ErrIopsNotSupported
+	}
+	if storage.StorageType == "io1" && storage.Iops <= 0 && actDB != nil {
+		log.Errorf("%s", ErrIopsNotFound.Error())
+		return ErrIopsNotFound
+	}
+
+	return nil
+}
+
+// esSummary struct is the source and restore settings and modified settings and upgrade result and query runs and phases variable
+type esSummary struct {
+	EnvName          string
+	Region           string
+	SourceID         string
+	Replica          bool   // read replica of SourceID, no snapshot is used
+	DBClusterID      string // restored db cluster, empty if not cluster
+	SnapshotID       string
+	SnapshotTime     *time.Time // nil if not known
+	DBInstanceClass  string
+	Storage          storageSettings
+	ModifiedSettings []string
+	Encrypted        bool
+	KmsKeyID         string
+	Upgrade          *upgradeResult // nil if not upgraded
+	Cost             *costResult    // nil if price not found
+	Runs             []esRun
+	Phases           []phaseResult
+}
+
+// costResult struct is the Lifetime and Cost variable
+type costResult struct {
+	Lifetime time.Duration
+	Cost     float64
+}
+
+// upgradeResult struct is the FromVersion and ToVersion and Time variable
+type upgradeResult struct {
+	FromVersion string
+	ToVersion   string
+	Time        time.Duration
+}
+
+// esRun struct is the DBIdentifier and EngineVersion and Queries and Times and Rows and Checksums and Files and Migrations and MigrationTimes variable
+type esRun struct {
+	DBIdentifier   string
+	EngineVersion  string
+	Queries        []query.Query
+	Times          []time.Duration
+	Rows           []int64
+	Checksums      []string // sha256 of result set
+	Files          []string // empty string if not written to file
+	Migrations     []query.Query
+	MigrationTimes []time.Duration
+	Masks          []query.Query // UPDATE statements of masking rules
+	MaskTimes      []time.Duration
+	MaskErr        error // nil if masking succeeded
+}
+
+func (s *esSummary) getText() string {
+	// show restore settings
+	iopsText := "-"
+	if s.Storage.Iops > 0 {
+		iopsText = fmt.Sprintf("%d", s.Storage.Iops)
+	}
+	totalText := "\nrestore settings:\n"
+	totalText += fmt.Sprintf("  region           : %s\n", s.Region)
+	if s.Replica {
+		totalText += fmt.Sprintf("  read replica of  : %s\n", s.SourceID)
+	} else {
+		totalText += fmt.Sprintf("  db snapshot      : %s\n", s.SnapshotID)
+	}
+	// storage of Aurora is managed by the cluster
+	if s.DBClusterID != "" {
+		totalText += fmt.Sprintf("  db cluster       : %s\n", s.DBClusterID)
+	}
+	totalText += fmt.Sprintf("  instance class   : %s\n", s.DBInstanceClass)
+	if s.DBClusterID == "" {
+		totalText += fmt.Sprintf("  storage type     : %s\n", s.Storage.StorageType)
+		totalText += fmt.Sprintf("  iops             : %s\n", iopsText)
+		totalText += fmt.Sprintf("  allocated storage: %d GB\n", s.Storage.AllocatedStorage)
+	}
+	modifiedText := "none"
+	if len(s.ModifiedSettings) > 0 {
+		modifiedText = strings.Join(s.ModifiedSettings, ", ")
+	}
+	totalText += fmt.Sprintf("  modified settings: %s\n", modifiedText)
+	totalText += fmt.Sprintf("  encrypted        : %t\n", s.Encrypted)
+	if s.Encrypted && s.KmsKeyID != "" {
+		totalText += fmt.Sprintf("  kms key id       : %s\n", s.KmsKeyID)
+	}
+
+	// show upgrade result
+	if s.Upgrade != nil {
+		totalText += "\nupgrade result:\n"
+		totalText += fmt.Sprintf("  engine version : %s -> %s\n", s.Upgrade.FromVersion, s.Upgrade.ToVersion)
+		totalText += fmt.Sprintf("  upgrade runtime: %s\n", s.Upgrade.Time.String())
+	}
+
+	for _, run := range s.Runs {
+		if len(run.Migrations) > 0 {
+			totalText += run.getMigrationText(nil)
+		}
+		if len(run.Masks) > 0 {
+			totalText += run.getMaskText()
+		}
+		totalText += run.getText(len(s.Runs) > 1)
+	}
+
+	// show cost estimate
+	if s.Cost != nil {
+		totalText += "\ncost estimate:\n"
+		totalText += fmt.Sprintf("  instance lifetime: %s\n", s.Cost.Lifetime.String())
+		totalText += fmt.Sprintf("  estimated cost   : %.4f\n", s.Cost.Cost)
+	}
+
+	return totalText
+}
+
+func (r *esRun) getText(showID bool) string {
+	// show total time
+	var total float64
+	totalText := "\nruntime result:\n"
+	if showID {
+		totalText = fmt.Sprintf("\nruntime result: %s (%s)\n", r.DBIdentifier, r.EngineVersion)
+	}
+	for i, time := range r.Times {
+		total += time.Seconds()
+		totalText += fmt.Sprintf("  query name   : %s\n  query runtime: %s\n", r.Queries[i].Name, time.String())
+		// two runs returned identical data if the rows and checksum are same
+		if i < len(r.Rows) && i < len(r.Checksums) {
+			totalText += fmt.Sprintf("  result rows  : %d\n  result sha256: %s\n", r.Rows[i], r.Checksums[i])
+		}
+		totalText += "\n"
+	}
+
+	hour := int(total) / 3600
+	minute := (int(total) - hour*3600) / 60
+	second := total - float64(hour*3600) - float64(minute*60)
+
+	totalText += "--------------------------------\n"
+	timeText := fmt.Sprintf("  total runtime: %.3f sec\n", second)
+	if minute > 0 {
+		timeText = fmt.Sprintf("  total runtime: %d m %.3f sec\n", minute, second)
+	}
+	if hour > 0 {
+		timeText = fmt.Sprintf("  total runtime: %d h %d m %.3f sec\n", hour, minute, second)
+	}
+	totalText += timeText
+
+	return totalText
+}
+
+// show the runtime of applied masking rules
+// and if masking failed, show the failed rule and that the queries are not run
+func (r *esRun) getMaskText() string {
+	totalText := fmt.Sprintf("\nmasking result: %s\n", r.DBIdentifier)
+	for i, time := range r.MaskTimes {
+		totalText += fmt.Sprintf("  mask name   : %s\n  mask runtime: %s\n\n", r.Masks[i].Name, time.String())
+	}
+
+	if r.MaskErr == nil {
+		return totalText
+	}
+
+	failed := len(r.MaskTimes)
+	if failed < len(r.Masks) {
+		totalText += fmt.Sprintf("  mask name   : %s\n  mask error  : %s\n\n", r.Masks[failed].Name, r.MaskErr.Error())
+	}
+	totalText += "--------------------------------\n"
+	totalText += fmt.Sprintf("  applied %d of %d masking rules, queries are not run\n", failed, len(r.Masks))
+
+	return totalText
+}
+
+// show the runtime of applied statements
+// and if err is not nil, show the failed statement and not applied statements
+func (r *esRun) getMigrationText(err error) string {
+	totalText := fmt.Sprintf("\nmigration result: %s\n", r.DBIdentifier)
+	for i, time := range r.MigrationTimes {
+		totalText += fmt.Sprintf("  statement name   : %s\n  statement runtime: %s\n\n", r.Migrations[i].Name, time.String())
+	}
+
+	if err == nil {
+		return totalText
+	}
+
+	failed := len(r.MigrationTimes)
+	if failed < len(r.Migrations) {
+		totalText += fmt.Sprintf("  statement name   : %s\n  statement error  : %s\n\n", r.Migrations[failed].Name, err.Error())
+
+		for _, migration := range r.Migrations[failed+1:] {
+			totalText += fmt.Sprintf("  not applied      : %s\n", migration.Name)
+		}
+	}
+	totalText += "--------------------------------\n"
+	totalText += fmt.Sprintf("  applied %d of %d statements\n", failed, len(r.Migrations))
+
+	return totalText
+}
@@ -1,100 +1,182 @@
-package command
-
-import (
-	"flag"
-	"fmt"
-
-	"github.com/uchimanajet7/rds-try/utils"
-)
-
-// LsCommand struct is the *Command and OptSnap variable
-type LsCommand struct {
-	*Command
-	OptSnap bool
-}
-
-// Help is the show help text
-func (c *LsCommand) Help() string {
-	// to-do: removal of the fixed value
-	helpText := fmt.Sprintf("\nUsage: %s ls [options]\n\n", utils.GetAppName())
-	helpText += "Options:\n"
-	helpText += "  -s, --snap  include own db snapshots to list\n"
-
-	return helpText
-}
-
-// Synopsis is the show short help text
-func (c *LsCommand) Synopsis() string {
-	return "list up own db instances and snapshots"
-}
-
-// Run is the start command
-func (c *LsCommand) Run(args []string) int {
-	log.Infof("start command : ls")
-
-	// reset flag
-	fs := flag.NewFlagSet("ls", flag.ExitOnError)
-
-	// register flag name
-	fs.BoolVar(&c.OptSnap, "snap", false, "include own db snapshots to list")
-	fs.BoolVar(&c.OptSnap, "s", false, "include own db snapshots to list")
-
-	fs.Usage = func() { fmt.Println(c.Help()) }
-	err := fs.Parse(args)
-	if err != nil {
-		log.Errorf("%s", err.Error())
-		return 1
-	}
-
-	err = c.runDetails(fs)
-	if err != nil {
-		log.Errorf("%s", err.Error())
-		return 1
-	}
-
-	log.Infof("end command : ls")
-
-	return 0
-}
-
-func (c *LsCommand) runDetails(f *flag.FlagSet) error {
-	// to get list created in this tool
-	dbList, err := c.DescribeDBInstancesByTags()
-	if err != nil {
-		return err
-	}
-
-	// show db list
-	if len(dbList) <= 0 {
-		fmt.Printf("\ndb instance list not exist\n")
-	} else {
-		fmt.Printf("\nlist of own db instance\n")
-		for i, db := range dbList {
-			fmt.Printf("  [% d] DB Instance: %s\n", i+1, *db.DBInstanceIdentifier)
-		}
-	}
-	// blank new line
-	fmt.Println("")
-
-	if c.OptSnap {
-		// to get list created in this tool
-		snapList, err := c.DescribeDBSnapshotsByTags()
-		if err != nil {
-			return err
-		}
-
-		// show snapshot list
-		if len(snapList) <= 0 {
-			fmt.Printf("db snapshot list not exist\n")
-		} else {
-			fmt.Printf("list of own db snapshot\n")
-			for i, snap := range snapList {
-				fmt.Printf("  [% d] DB Snapshot: %s\n", i+1, *snap.DBSnapshotIdentifier)
-			}
-		}
-		// blank new line
-		fmt.Println("")
-	}
-
-	return nil
-}
+package command
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/rds"
+
+	"github.com/uchimanajet7/rds-try/price"
+	"github.com/uchimanajet7/rds-try/utils"
+)
+
+// LsCommand struct is the *Command and OptSnap variable
+type LsCommand struct {
+	*Command
+	OptSnap bool
+}
+
+// Help is the show help text
+func (c *LsCommand) Help() string {
+	// to-do: removal of the fixed value
+	helpText := fmt.Sprintf("\nUsage: %s ls [options]\n\n", utils.GetAppName())
+	helpText += "Options:\n"
+	helpText += "  -s, --snap  include own db snapshots to list\n"
+
+	return helpText
+}
+
+// Synopsis is the show short help text
+func (c *LsCommand) Synopsis() string {
+	return "list up own db instances and snapshots"
+}
+
+// Run is the start command
+func (c *LsCommand) Run(args []string) int {
+	log.Infof("start command : ls")
+
+	// reset flag
+	fs := flag.NewFlagSet("ls", flag.ExitOnError)
+
+	// register flag name
+	fs.BoolVar(&c.OptSnap, "snap", false, "include own db snapshots to list")
+	fs.BoolVar(&c.OptSnap, "s", false, "include own db snapshots to list")
+
+	fs.Usage = func() { fmt.Println(c.Help()) }
+	err := fs.Parse(args)
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return 1
+	}
+
+	err = c.runDetails(fs)
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return 1
+	}
+
+	log.Infof("end command : ls")
+
+	return 0
+}
+
+func (c *LsCommand) runDetails(f *flag.FlagSet) error {
+	// load price file for running cost
+	prices, err := loadPrices()
+	if err != nil {
+		return err
+	}
+	var totalCost float64
+
+	// "copy_region" is also listed if specified
+	commands := c.GetRegionCommands()
+	for _, command := range commands {
+		regionText := ""
+		if len(commands) > 1 {
+			regionText = fmt.Sprintf(" in %s", command.RDSConfig.Region)
+		}
+
+		// to get list created in this tool
+		dbList, err := command.DescribeDBInstancesByTags()
+		if err != nil {
+			return err
+		}
+
+		// show db list
+		if len(dbList) <= 0 {
+			fmt.Printf("\ndb instance list not exist%s\n", regionText)
+		} else {
+			fmt.Printf("\nlist of own db instance%s\n", regionText)
+			for i, db := range dbList {
+				costText := ""
+				if prices != nil {
+					cost, text := getRunningCost(command, prices, db)
+					totalCost += cost
+					costText = text
+				}
+				fmt.Printf("  [% d] DB Instance: %s%s%s\n", i+1, *db.DBInstanceIdentifier, getReplicaText(db), costText)
+			}
+		}
+		// blank new line
+		fmt.Println("")
+
+		// to get Aurora DB Cluster list created in this tool
+		// the writer db instance is listed in db instance list with its cost
+		clusterList, err := command.DescribeDBClustersByTags()
+		if err != nil {
+			return err
+		}
+
+		// show cluster list, nothing is shown if not exist
+		if len(clusterList) > 0 {
+			fmt.Printf("list of own db cluster%s\n", regionText)
+			for i, cluster := range clusterList {
+				fmt.Printf("  [% d] DB Cluster: %s\n", i+1, *cluster.DBClusterIdentifier)
+			}
+			// blank new line
+			fmt.Println("")
+		}
+
+		if c.OptSnap {
+			// to get list created in this tool
+			snapList, err := command.DescribeDBSnapshotsByTags()
+			if err != nil {
+				return err
+			}
+
+			// show snapshot list
+			if len(snapList) <= 0 {
+				fmt.Printf("db snapshot list not exist%s\n", regionText)
+			} else {
+				fmt.Printf("list of own db snapshot%s\n", regionText)
+				for i, snap := range snapList {
+					fmt.Printf("  [% d] DB Snapshot: %s\n", i+1, *snap.DBSnapshotIdentifier)
+				}
+			}
+			// blank new line
+			fmt.Println("")
+
+			// to get cluster snapshot list created in this tool
+			clusterSnapList, err := command.DescribeDBClusterSnapshotsByTags()
+			if err != nil {
+				return err
+			}
+
+			// show cluster snapshot list, nothing is shown if not exist
+			if len(clusterSnapList) > 0 {
+				fmt.Printf("list of own db cluster snapshot%s\n", regionText)
+				for i, snap := range clusterSnapList {
+					fmt.Printf("  [% d] DB Cluster Snapshot: %s\n", i+1, *snap.DBClusterSnapshotIdentifier)
+				}
+				// blank new line
+				fmt.Println("")
+			}
+		}
+	}
+
+	// show accumulated running cost
+	if prices != nil {
+		fmt.Printf("total running cost: %.4f\n\n", totalCost)
+	}
+
+	return nil
+}
+
+// running cost is estimated by the lifetime from rt_time tag
+// return 0 and the text of reason if it can not be estimated
+func getRunningCost(command *Command, prices *price.Prices, db *rds.DBInstance) (float64, string) {
+	createdTime, err := command.GetCreatedTime(db)
+	if err != nil {
+		return 0, " (running: unknown)"
+	}
+	lifetime := time.Now().Sub(createdTime)
+	lifetimeText := (lifetime - lifetime%time.Second).String()
+
+	cost, err := prices.Estimate(getEstimateArgs(db, command.RDSConfig.Region, lifetime))
+	if err != nil {
+		return 0, fmt.Sprintf(" (running: %s, cost: unknown)", lifetimeText)
+	}
+
+	return cost, fmt.Sprintf(" (running: %s, cost: %.4f)", lifetimeText, cost)
+}
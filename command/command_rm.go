@@ -1,194 +1,485 @@
-package command
-
-import (
-	"bufio"
-	"errors"
-	"flag"
-	"fmt"
-	"os"
-	"os/signal"
-	"strings"
-
-	"github.com/aws/aws-sdk-go/service/rds"
-
-	"github.com/uchimanajet7/rds-try/utils"
-)
-
-// RmCommand struct is the *Command and OptSnap and OptForce and OptItem variable
-type RmCommand struct {
-	*Command
-	OptSnap  bool
-	OptForce bool
-	OptItem  string
-}
-
-// ErrInterruptedAskDelete is the "OS Interrupted Ask Delete" error
-var ErrInterruptedAskDelete = errors.New("OS Interrupted Ask Delete")
-
-// Help is the show help text
-func (c *RmCommand) Help() string {
-	// to-do: removal of the fixed value
-	helpText := fmt.Sprintf("\nUsage: %s rm [options]\n\n", utils.GetAppName())
-	helpText += "Options:\n"
-	helpText += "  -s, --snap   list up own db snapshots\n"
-	helpText += "  -f, --force  forced delete without confirmation\n"
-
-	return helpText
-}
-
-// Synopsis is the show short help text
-func (c *RmCommand) Synopsis() string {
-	return "delete your created db instances and snapshots"
-}
-
-// Run is the start command
-func (c *RmCommand) Run(args []string) int {
-	log.Infof("start command : rm")
-
-	// reset flag
-	fs := flag.NewFlagSet("rm", flag.ExitOnError)
-
-	// register flag name
-	fs.BoolVar(&c.OptSnap, "snap", false, "include own db snapshots to delete")
-	fs.BoolVar(&c.OptSnap, "s", false, "include own db snapshots to delete")
-	fs.BoolVar(&c.OptForce, "force", false, "forced delete without confirmation")
-	fs.BoolVar(&c.OptForce, "f", false, "forced delete without confirmation")
-
-	fs.Usage = func() { fmt.Println(c.Help()) }
-	err := fs.Parse(args)
-	if err != nil {
-		log.Errorf("%s", err.Error())
-		return 1
-	}
-
-	err = c.runDetails(fs)
-	if err != nil {
-		log.Errorf("%s", err.Error())
-		return 1
-	}
-	log.Infof("end command : rm")
-
-	return 0
-}
-
-func (c *RmCommand) runDetails(f *flag.FlagSet) error {
-	// to get list created in this tool
-	dbList, err := c.DescribeDBInstancesByTags()
-	if err != nil {
-		return err
-	}
-	askCount := 0
-
-	// show db list
-	if len(dbList) <= 0 {
-		fmt.Printf("\ndb instance list not exist\n")
-	} else {
-		askCount++
-		fmt.Printf("\nlist of own db instance\n")
-		for i, db := range dbList {
-			fmt.Printf("  [% d] DB Instance: %s\n", i+1, *db.DBInstanceIdentifier)
-		}
-	}
-	// blank new line
-	fmt.Println("")
-
-	var snapList []*rds.DBSnapshot
-	if c.OptSnap {
-		// to get list created in this tool
-		snapList, err = c.DescribeDBSnapshotsByTags()
-		if err != nil {
-			return err
-		}
-
-		// show snapshot list
-		if len(snapList) <= 0 {
-			fmt.Printf("db snapshot list not exist\n")
-		} else {
-			askCount++
-			fmt.Printf("list of own db snapshot\n")
-			for i, snap := range snapList {
-				fmt.Printf("  [% d] DB Snapshot: %s\n", i+1, *snap.DBSnapshotIdentifier)
-			}
-		}
-		// blank new line
-		fmt.Println("")
-	}
-
-	// list does not exist
-	if askCount <= 0 {
-		return nil
-	}
-
-	// confirm delete
-	var askResp string
-	if c.OptForce {
-		askResp = "yes"
-	} else {
-		askResp, err = askQuestion("you want to delete all of those? [y/n]:")
-		if err != nil {
-			log.Errorf("%s", err.Error())
-			return err
-		}
-	}
-	// blank new line
-	fmt.Println("")
-
-	switch askResp {
-	case "y", "Y", "yes", "YES", "Yes":
-		// delete db instance
-		err = c.DeleteDBResources(dbList)
-		if err != nil {
-			return err
-		}
-		// delete db snapshot
-		if c.OptSnap {
-			err = c.DeleteDBResources(snapList)
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
-
-// this method copied
-// see also
-// https://github.com/mitchellh/cli
-func askQuestion(query string) (string, error) {
-	// show query string
-	fmt.Printf("%s ", query)
-
-	// Register for interrupts so that we can catch it and immediately
-	// return...
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, os.Interrupt)
-	defer signal.Stop(sigCh)
-
-	// Ask for input in a go-routine so that we can ignore it.
-	errCh := make(chan error, 1)
-	lineCh := make(chan string, 1)
-	go func() {
-		r := bufio.NewReader(os.Stdin)
-		line, err := r.ReadString('\n')
-		if err != nil {
-			errCh <- err
-			return
-		}
-
-		lineCh <- strings.TrimRight(line, "\r\n")
-	}()
-
-	select {
-	case err := <-errCh:
-		return "", err
-	case line := <-lineCh:
-		return line, nil
-	case <-sigCh:
-		// Print a newline so that any further output starts properly
-		// on a new line.
-		fmt.Println("")
-
-		return "", ErrInterruptedAskDelete
-	}
-}
+package command
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/rds"
+
+	"github.com/uchimanajet7/rds-try/notify"
+	"github.com/uchimanajet7/rds-try/utils"
+)
+
+// RmCommand struct is the *Command and OptSnap and OptForce and OptItem and OptExpired and OptRetention variable
+type RmCommand struct {
+	*Command
+	OptSnap      bool
+	OptForce     bool
+	OptItem      string
+	OptExpired   bool
+	OptRetention bool
+}
+
+// ErrInterruptedAskDelete is the "OS Interrupted Ask Delete" error
+var ErrInterruptedAskDelete = errors.New("OS Interrupted Ask Delete")
+
+// Help is the show help text
+func (c *RmCommand) Help() string {
+	// to-do: removal of the fixed value
+	helpText := fmt.Sprintf("\nUsage: %s rm [options]\n\n", utils.GetAppName())
+	helpText += "Options:\n"
+	helpText += "  -s, --snap       list up own db snapshots\n"
+	helpText += "  -f, --force      forced delete without confirmation\n"
+	helpText += "      --expired    delete only expired by rt_expire tag\n"
+	helpText += "      --retention  delete only snapshots over retention_count and retention_days\n"
+
+	return helpText
+}
+
+// Synopsis is the show short help text
+func (c *RmCommand) Synopsis() string {
+	return "delete your created db instances and snapshots"
+}
+
+// Run is the start command
+func (c *RmCommand) Run(args []string) int {
+	log.Infof("start command : rm")
+
+	// reset flag
+	fs := flag.NewFlagSet("rm", flag.ExitOnError)
+
+	// register flag name
+	fs.BoolVar(&c.OptSnap, "snap", false, "include own db snapshots to delete")
+	fs.BoolVar(&c.OptSnap, "s", false, "include own db snapshots to delete")
+	fs.BoolVar(&c.OptForce, "force", false, "forced delete without confirmation")
+	fs.BoolVar(&c.OptForce, "f", false, "forced delete without confirmation")
+	fs.BoolVar(&c.OptExpired, "expired", false, "delete only expired by rt_expire tag")
+	fs.BoolVar(&c.OptRetention, "retention", false, "delete only snapshots over retention_count and retention_days")
+
+	fs.Usage = func() { fmt.Println(c.Help()) }
+	err := fs.Parse(args)
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return 1
+	}
+
+	err = c.runDetails(fs)
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return 1
+	}
+	log.Infof("end command : rm")
+
+	return 0
+}
+
+// rmTarget struct is the command and resource lists variable of each region
+type rmTarget struct {
+	command         *Command
+	dbList          []*rds.DBInstance
+	clusterList     []*rds.DBCluster
+	snapList        []*rds.DBSnapshot
+	clusterSnapList []*rds.DBClusterSnapshot
+}
+
+func (c *RmCommand) runDetails(f *flag.FlagSet) error {
+	// only snapshots are the target of retention policy
+	if c.OptRetention {
+		return c.runRetention()
+	}
+
+	commands := c.GetRegionCommands()
+	targets := make([]rmTarget, 0, len(commands))
+	askCount := 0
+
+	for _, command := range commands {
+		regionText := ""
+		if len(commands) > 1 {
+			regionText = fmt.Sprintf(" in %s", command.RDSConfig.Region)
+		}
+
+		// to get list created in this tool
+		dbList, err := command.DescribeDBInstancesByTags()
+		if err != nil {
+			return err
+		}
+		if c.OptExpired {
+			dbList, err = filterExpiredDBInstances(command, dbList)
+			if err != nil {
+				return err
+			}
+		}
+
+		// show db list
+		if len(dbList) <= 0 {
+			fmt.Printf("\ndb instance list not exist%s\n", regionText)
+		} else {
+			askCount++
+			fmt.Printf("\nlist of own db instance%s\n", regionText)
+			for i, db := range dbList {
+				fmt.Printf("  [% d] DB Instance: %s%s\n", i+1, *db.DBInstanceIdentifier, getReplicaText(db))
+			}
+		}
+		// blank new line
+		fmt.Println("")
+
+		// to get Aurora DB Cluster list created in this tool
+		clusterList, err := command.DescribeDBClustersByTags()
+		if err != nil {
+			return err
+		}
+		if c.OptExpired {
+			clusterList, err = filterExpiredDBClusters(command, clusterList)
+			if err != nil {
+				return err
+			}
+		}
+
+		// show cluster list, nothing is shown if not exist
+		if len(clusterList) > 0 {
+			askCount++
+			fmt.Printf("list of own db cluster%s\n", regionText)
+			for i, cluster := range clusterList {
+				fmt.Printf("  [% d] DB Cluster: %s\n", i+1, *cluster.DBClusterIdentifier)
+			}
+			// blank new line
+			fmt.Println("")
+		}
+
+		var snapList []*rds.DBSnapshot
+		var clusterSnapList []*rds.DBClusterSnapshot
+		if c.OptSnap {
+			// to get list created in this tool
+			snapList, err = command.DescribeDBSnapshotsByTags()
+			if err != nil {
+				return err
+			}
+			if c.OptExpired {
+				snapList, err = filterExpiredDBSnapshots(command, snapList)
+				if err != nil {
+					return err
+				}
+			}
+
+			// show snapshot list
+			if len(snapList) <= 0 {
+				fmt.Printf("db snapshot list not exist%s\n", regionText)
+			} else {
+				askCount++
+				fmt.Printf("list of own db snapshot%s\n", regionText)
+				for i, snap := range snapList {
+					fmt.Printf("  [% d] DB Snapshot: %s\n", i+1, *snap.DBSnapshotIdentifier)
+				}
+			}
+			// blank new line
+			fmt.Println("")
+
+			// to get cluster snapshot list created in this tool
+			clusterSnapList, err = command.DescribeDBClusterSnapshotsByTags()
+			if err != nil {
+				return err
+			}
+			if c.OptExpired {
+				clusterSnapList, err = filterExpiredDBClusterSnapshots(command, clusterSnapList)
+				if err != nil {
+					return err
+				}
+			}
+
+			// show cluster snapshot list, nothing is shown if not exist
+			if len(clusterSnapList) > 0 {
+				askCount++
+				fmt.Printf("list of own db cluster snapshot%s\n", regionText)
+				for i, snap := range clusterSnapList {
+					fmt.Printf("  [% d] DB Cluster Snapshot: %s\n", i+1, *snap.DBClusterSnapshotIdentifier)
+				}
+				// blank new line
+				fmt.Println("")
+			}
+		}
+
+		targets = append(targets, rmTarget{
+			command:         command,
+			dbList:          dbList,
+			clusterList:     clusterList,
+			snapList:        snapList,
+			clusterSnapList: clusterSnapList,
+		})
+	}
+
+	// list does not exist
+	if askCount <= 0 {
+		return nil
+	}
+
+	return c.deleteTargets(targets)
+}
+
+// snapshots of "db_id" over "retention_count" and "retention_days" are deleted
+// the snapshots of other environments are not the target
+func (c *RmCommand) runRetention() error {
+	if !c.HasRetention() {
+		log.Errorf("%s", ErrRetentionNotFound.Error())
+		return ErrRetentionNotFound
+	}
+
+	commands := c.GetRegionCommands()
+	targets := make([]rmTarget, 0, len(commands))
+	askCount := 0
+
+	for _, command := range commands {
+		regionText := ""
+		if len(commands) > 1 {
+			regionText = fmt.Sprintf(" in %s", command.RDSConfig.Region)
+		}
+
+		snapList, clusterSnapList, err := command.DescribeOverRetentionSnapshots(nil)
+		if err != nil {
+			return err
+		}
+
+		// show snapshot list
+		if len(snapList)+len(clusterSnapList) <= 0 {
+			fmt.Printf("\nsnapshot over retention policy not exist%s\n", regionText)
+		} else {
+			askCount++
+			fmt.Printf("\nlist of own snapshot over retention policy%s\n", regionText)
+			for i, snap := range snapList {
+				fmt.Printf("  [% d] DB Snapshot: %s\n", i+1, *snap.DBSnapshotIdentifier)
+			}
+			for i, snap := range clusterSnapList {
+				fmt.Printf("  [% d] DB Cluster Snapshot: %s\n", len(snapList)+i+1, *snap.DBClusterSnapshotIdentifier)
+			}
+		}
+		// blank new line
+		fmt.Println("")
+
+		targets = append(targets, rmTarget{
+			command:         command,
+			snapList:        snapList,
+			clusterSnapList: clusterSnapList,
+		})
+	}
+
+	// list does not exist
+	if askCount <= 0 {
+		return nil
+	}
+
+	return c.deleteTargets(targets)
+}
+
+// confirm and delete resources of all targets
+// db snapshots are listed in targets only if those are the target
+func (c *RmCommand) deleteTargets(targets []rmTarget) error {
+	// confirm delete
+	var askResp string
+	var err error
+	if c.OptForce {
+		askResp = "yes"
+	} else {
+		askResp, err = askQuestion("you want to delete all of those? [y/n]:")
+		if err != nil {
+			log.Errorf("%s", err.Error())
+			return err
+		}
+	}
+	// blank new line
+	fmt.Println("")
+
+	switch askResp {
+	case "y", "Y", "yes", "YES", "Yes":
+		var deleted []string
+		for _, target := range targets {
+			// delete db instance
+			err = target.command.DeleteDBResources(target.dbList)
+			if err != nil {
+				c.sendNotify(deleted)
+				return err
+			}
+			deleted = append(deleted, getDeletedResourceTexts(target.command, target.dbList)...)
+			// delete db cluster after the db instances of cluster
+			err = target.command.DeleteDBResources(target.clusterList)
+			if err != nil {
+				c.sendNotify(deleted)
+				return err
+			}
+			deleted = append(deleted, getDeletedResourceTexts(target.command, target.clusterList)...)
+			// delete db snapshot
+			err = target.command.DeleteDBResources(target.snapList)
+			if err != nil {
+				c.sendNotify(deleted)
+				return err
+			}
+			deleted = append(deleted, getDeletedResourceTexts(target.command, target.snapList)...)
+			err = target.command.DeleteDBResources(target.clusterSnapList)
+			if err != nil {
+				c.sendNotify(deleted)
+				return err
+			}
+			deleted = append(deleted, getDeletedResourceTexts(target.command, target.clusterSnapList)...)
+		}
+		c.sendNotify(deleted)
+	}
+
+	return nil
+}
+
+// notify the deleted resources, nothing is notified if not deleted
+func (c *RmCommand) sendNotify(deleted []string) {
+	if len(deleted) <= 0 {
+		return
+	}
+
+	c.Notifier.Send(&notify.Event{
+		Command:   "rm",
+		Status:    notify.StatusDeleted,
+		Name:      c.Name,
+		Resources: deleted,
+		Time:      time.Now(),
+	})
+}
+
+// ex. "DB Instance: rds-try-test-db-1 in us-west-2"
+func getDeletedResourceTexts(command *Command, rdstypes interface{}) []string {
+	var texts []string
+	switch rdstype := rdstypes.(type) {
+	case []*rds.DBSnapshot:
+		for _, item := range rdstype {
+			texts = append(texts, getCreatedResourceText(createdResource{command: command, resource: item}))
+		}
+	case []*rds.DBInstance:
+		for _, item := range rdstype {
+			texts = append(texts, getCreatedResourceText(createdResource{command: command, resource: item}))
+		}
+	case []*rds.DBCluster:
+		for _, item := range rdstype {
+			texts = append(texts, getCreatedResourceText(createdResource{command: command, resource: item}))
+		}
+	case []*rds.DBClusterSnapshot:
+		for _, item := range rdstype {
+			texts = append(texts, getCreatedResourceText(createdResource{command: command, resource: item}))
+		}
+	}
+
+	return texts
+}
+
+// only db instances past the time of rt_expire tag are the target
+// the db instance without rt_expire tag is never the target
+func filterExpiredDBInstances(command *Command, dbList []*rds.DBInstance) ([]*rds.DBInstance, error) {
+	var expiredList []*rds.DBInstance
+	for _, db := range dbList {
+		expired, err := command.IsExpired(db)
+		if err != nil {
+			return nil, err
+		}
+
+		if expired {
+			expiredList = append(expiredList, db)
+		}
+	}
+
+	return expiredList, nil
+}
+
+// only db snapshots past the time of rt_expire tag are the target
+// the db snapshot without rt_expire tag is never the target
+func filterExpiredDBSnapshots(command *Command, snapList []*rds.DBSnapshot) ([]*rds.DBSnapshot, error) {
+	var expiredList []*rds.DBSnapshot
+	for _, snap := range snapList {
+		expired, err := command.IsExpired(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		if expired {
+			expiredList = append(expiredList, snap)
+		}
+	}
+
+	return expiredList, nil
+}
+
+// only db clusters past the time of rt_expire tag are the target
+// the db cluster without rt_expire tag is never the target
+func filterExpiredDBClusters(command *Command, clusterList []*rds.DBCluster) ([]*rds.DBCluster, error) {
+	var expiredList []*rds.DBCluster
+	for _, cluster := range clusterList {
+		expired, err := command.IsExpired(cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		if expired {
+			expiredList = append(expiredList, cluster)
+		}
+	}
+
+	return expiredList, nil
+}
+
+// only db cluster snapshots past the time of rt_expire tag are the target
+// the db cluster snapshot without rt_expire tag is never the target
+func filterExpiredDBClusterSnapshots(command *Command, snapList []*rds.DBClusterSnapshot) ([]*rds.DBClusterSnapshot, error) {
+	var expiredList []*rds.DBClusterSnapshot
+	for _, snap := range snapList {
+		expired, err := command.IsExpired(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		if expired {
+			expiredList = append(expiredList, snap)
+		}
+	}
+
+	return expiredList, nil
+}
+
+// this method copied
+// see also
+// https://github.com/mitchellh/cli
+func askQuestion(query string) (string, error) {
+	// show query string
+	fmt.Printf("%s ", query)
+
+	// Register for interrupts so that we can catch it and immediately
+	// return...
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+
+	// Ask for input in a go-routine so that we can ignore it.
+	errCh := make(chan error, 1)
+	lineCh := make(chan string, 1)
+	go func() {
+		r := bufio.NewReader(os.Stdin)
+		line, err := r.ReadString('\n')
+		if err != nil {
+			errCh <- err
+			return
+		}
+
+		lineCh <- strings.TrimRight(line, "\r\n")
+	}()
+
+	select {
+	case err := <-errCh:
+		return "", err
+	case line := <-lineCh:
+		return line, nil
+	case <-sigCh:
+		// Print a newline so that any further output starts properly
+		// on a new line.
+		fmt.Println("")
+
+		return "", ErrInterruptedAskDelete
+	}
+}
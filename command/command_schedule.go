@@ -0,0 +1,303 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/uchimanajet7/rds-try/config"
+	"github.com/uchimanajet7/rds-try/cron"
+	"github.com/uchimanajet7/rds-try/history"
+	"github.com/uchimanajet7/rds-try/utils"
+)
+
+// ScheduleCommand struct is the *Command and OptList and ConfigFile and Schedules and running environments variable
+type ScheduleCommand struct {
+	*Command
+	OptList      bool
+	ConfigFile   string
+	Schedules    []config.ScheduleConfig
+	entries      []scheduleEntry
+	running      map[string]bool
+	runningMutex sync.Mutex
+	wait         sync.WaitGroup
+	runner       func(args []string) ([]byte, error) // run es with arguments, nil if run by executable
+}
+
+// scheduleEntry struct is the ScheduleConfig and parsed cron Schedule variable
+type scheduleEntry struct {
+	config.ScheduleConfig
+	schedule *cron.Schedule
+}
+
+// name of rds environment if not specified in schedule
+const defaultScheduleName = "default"
+
+var (
+	// ErrScheduleNotFound is the "[[schedule]] section not found in file" error
+	ErrScheduleNotFound = errors.New("[[schedule]] section not found in file")
+)
+
+// Help is the show help text
+func (c *ScheduleCommand) Help() string {
+	// to-do: removal of the fixed value
+	helpText := fmt.Sprintf("\nUsage: %s schedule [options]\n\n", utils.GetAppName())
+	helpText += "Options:\n"
+	helpText += "  -l, --list  show schedules and next run time\n"
+
+	return helpText
+}
+
+// Synopsis is the show short help text
+func (c *ScheduleCommand) Synopsis() string {
+	return "run es on schedule of config file"
+}
+
+// Run is the start command
+func (c *ScheduleCommand) Run(args []string) int {
+	log.Infof("start command : schedule")
+
+	// reset flag
+	fs := flag.NewFlagSet("schedule", flag.ExitOnError)
+
+	// register flag name
+	fs.BoolVar(&c.OptList, "list", false, "show schedules and next run time")
+	fs.BoolVar(&c.OptList, "l", false, "show schedules and next run time")
+
+	fs.Usage = func() { fmt.Println(c.Help()) }
+	err := fs.Parse(args)
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return 1
+	}
+
+	err = c.runDetails(fs)
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return 1
+	}
+	log.Infof("end command : schedule")
+
+	return 0
+}
+
+func (c *ScheduleCommand) runDetails(f *flag.FlagSet) error {
+	// all schedules are checked before running
+	entries, err := parseSchedules(c.Schedules)
+	if err != nil {
+		return err
+	}
+	c.entries = entries
+
+	if c.OptList {
+		fmt.Println(c.getListText(time.Now()))
+		return nil
+	}
+
+	// SIGINT and SIGTERM stop scheduling, and wait for running es
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	log.Infof("scheduler started with %d schedules", len(c.entries))
+	for {
+		// checked at the beginning of every minute
+		now := time.Now()
+		next := now.Truncate(time.Minute).Add(time.Minute)
+		select {
+		case <-time.After(next.Sub(now)):
+			c.startMatched(next)
+		case sig := <-sigCh:
+			log.Infof("scheduler stopped by %s, wait for running es", sig)
+			c.wait.Wait()
+			return nil
+		}
+	}
+}
+
+// parse cron spec of all schedules
+func parseSchedules(schedules []config.ScheduleConfig) ([]scheduleEntry, error) {
+	if len(schedules) <= 0 {
+		log.Errorf("%s", ErrScheduleNotFound.Error())
+		return nil, ErrScheduleNotFound
+	}
+
+	entries := make([]scheduleEntry, 0, len(schedules))
+	for _, item := range schedules {
+		schedule, err := cron.Parse(item.Cron)
+		if err != nil {
+			log.Errorf("%s: %s", err.Error(), item.Cron)
+			return nil, err
+		}
+		if item.Name == "" {
+			item.Name = defaultScheduleName
+		}
+		entries = append(entries, scheduleEntry{ScheduleConfig: item, schedule: schedule})
+	}
+
+	return entries, nil
+}
+
+// ex.
+// [ 1] default: 0 2 * * * (next: 2015-02-26 02:00:00)
+func (c *ScheduleCommand) getListText(now time.Time) string {
+	listText := "list of schedule\n"
+	for i, entry := range c.entries {
+		nextText := "never"
+		if next := entry.schedule.Next(now); !next.IsZero() {
+			nextText = next.Format("2006-01-02 15:04:05")
+		}
+		listText += fmt.Sprintf("  [% d] %s: %s (next: %s)\n", i+1, entry.Name, entry.Cron, nextText)
+		listText += fmt.Sprintf("       es %s\n", strings.Join(entry.getArgs(), " "))
+	}
+
+	return listText
+}
+
+// start es of all schedules matched at t
+func (c *ScheduleCommand) startMatched(t time.Time) {
+	for _, entry := range c.entries {
+		if !entry.schedule.Match(t) {
+			continue
+		}
+
+		// the runs of same environment do not overlap
+		if !c.startRunning(entry.Name) {
+			log.Infof("skip schedule of %s: previous es is running", entry.Name)
+			c.writeHistory(&history.Entry{
+				Command: "schedule",
+				Name:    entry.Name,
+				Status:  history.StatusSkipped,
+				Message: "previous es is running",
+			})
+			continue
+		}
+
+		c.wait.Add(1)
+		go func(entry scheduleEntry) {
+			defer c.wait.Done()
+			defer c.endRunning(entry.Name)
+			c.runEntry(entry)
+		}(entry)
+	}
+}
+
+// return false if es of the environment is running
+func (c *ScheduleCommand) startRunning(name string) bool {
+	c.runningMutex.Lock()
+	defer c.runningMutex.Unlock()
+
+	if c.running == nil {
+		c.running = make(map[string]bool)
+	}
+	if c.running[name] {
+		return false
+	}
+	c.running[name] = true
+
+	return true
+}
+
+func (c *ScheduleCommand) endRunning(name string) {
+	c.runningMutex.Lock()
+	defer c.runningMutex.Unlock()
+
+	delete(c.running, name)
+}
+
+// run es of the schedule, and write the result to log and history
+func (c *ScheduleCommand) runEntry(entry scheduleEntry) {
+	args := entry.getArgs()
+	log.Infof("schedule start: %s es %s", entry.Name, strings.Join(args, " "))
+	c.writeHistory(&history.Entry{
+		Command: "schedule",
+		Name:    entry.Name,
+		Status:  history.StatusStarted,
+		Message: strings.Join(args, " "),
+	})
+
+	sTime := time.Now()
+	out, err := c.runES(entry.Name, args)
+	elapsed := time.Now().Sub(sTime)
+
+	result := &history.Entry{
+		Command: "schedule",
+		Name:    entry.Name,
+		Status:  history.StatusSucceeded,
+		Elapsed: elapsed,
+	}
+	if err != nil {
+		// the cause of failure is in the output of es
+		log.Errorf("schedule failed: %s %s", entry.Name, err.Error())
+		log.Errorf("es output of %s: %s", entry.Name, string(out))
+		result.Status = history.StatusFailed
+		result.Message = err.Error()
+		if tail := getOutputTail(out); tail != "" {
+			result.Message += ": " + tail
+		}
+	} else {
+		log.Debugf("es output of %s: %s", entry.Name, string(out))
+		log.Infof("schedule end: %s (%s)", entry.Name, elapsed.String())
+	}
+	c.writeHistory(result)
+}
+
+// the number of last lines of es output written to history
+const outputTailLines = 5
+
+// return the last lines of es output, the error of es is at the end
+func getOutputTail(out []byte) string {
+	lines := strings.Split(strings.TrimSpace(strings.Replace(string(out), "\r\n", "\n", -1)), "\n")
+	if len(lines) > outputTailLines {
+		lines = lines[len(lines)-outputTailLines:]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// es is run by another process of this executable
+// so that runs of other environments do not share signal and output
+func (c *ScheduleCommand) runES(name string, args []string) ([]byte, error) {
+	globals := []string{"-n", name}
+	if c.ConfigFile != "" {
+		globals = append([]string{"-c", c.ConfigFile}, globals...)
+	}
+	esArgs := append(append(globals, "es"), args...)
+
+	if c.runner != nil {
+		return c.runner(esArgs)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	return exec.Command(executable, esArgs...).CombinedOutput()
+}
+
+// the failure of history is shown in the log, and does not stop scheduling
+func (c *ScheduleCommand) writeHistory(entry *history.Entry) {
+	if c.HistoryFile == "" {
+		return
+	}
+
+	history.Append(c.HistoryFile, entry)
+}
+
+// options of es, "query" is added as "-q"
+func (e *scheduleEntry) getArgs() []string {
+	var args []string
+	if e.Query != "" {
+		args = append(args, "-q", e.Query)
+	}
+
+	return append(args, e.Options...)
+}
@@ -1,449 +1,2372 @@
-package command
-
-import (
-	"database/sql"
-	"fmt"
-	"io/ioutil"
-	"net/http"
-	"net/http/httptest"
-	"net/url"
-	"os"
-	"strings"
-	"testing"
-
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/service/rds"
-	testdb "github.com/erikstmartin/go-testdb"
-
-	"github.com/uchimanajet7/rds-try/config"
-	"github.com/uchimanajet7/rds-try/query"
-	"github.com/uchimanajet7/rds-try/utils"
-)
-
-// return "httptest.Server" need call close !!
-// and Command into OutConfig.Root need call remove !!
-func getTestClient(code int, body string) (*httptest.Server, *Command) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(code)
-		w.Header().Set("Content-Type", "application/xml")
-		fmt.Fprintln(w, body)
-	}))
-
-	transport := &http.Transport{
-		Proxy: func(req *http.Request) (*url.URL, error) {
-			return url.Parse(server.URL)
-		},
-	}
-
-	httpClient := &http.Client{Transport: transport}
-
-	// Override endpoints
-	testRegion := "rds-try-test-1"
-	awsConf := aws.NewConfig()
-	awsConf = awsConf.WithCredentials(credentials.NewStaticCredentials("awsAccesskey1", "awsSecretKey2", ""))
-	awsConf = awsConf.WithRegion(testRegion)
-	awsConf = awsConf.WithEndpoint(server.URL)
-	awsConf = awsConf.WithHTTPClient(httpClient)
-
-	awsRds := rds.New(awsConf)
-
-	testName := utils.GetAppName() + "-test"
-	tempDir, _ := ioutil.TempDir("", testName)
-	defer os.RemoveAll(tempDir)
-
-	out := config.OutConfig{
-		Root: tempDir,
-		File: true,
-		Bom:  true,
-	}
-
-	rds := config.RDSConfig{
-		MultiAz: false,
-		DBId:    utils.GetFormatedDBDisplayName(testName),
-		Region:  testRegion,
-		User:    "test-admin",
-		Pass:    "pass-pass",
-		Type:    "db.m3.medium",
-	}
-
-	cmdTest := &Command{
-		OutConfig: out,
-		RDSConfig: rds,
-		RDSClient: awsRds,
-		ARNPrefix: "arn:aws:rds:" + testRegion + ":" + "123456789" + ":",
-	}
-
-	return server, cmdTest
-}
-
-func TestDescribeDBInstances(t *testing.T) {
-	ts, tc := getTestClient(200, srDescribeDBInstancesResponse)
-	defer ts.Close()
-
-	input := &rds.DescribeDBInstancesInput{}
-	ri, err := tc.describeDBInstances(input)
-
-	if err != nil {
-		t.Errorf("[describeDBInstances] result error: %s", err.Error())
-	}
-	if len(ri) != 3 {
-		t.Errorf("DB Instance count not match: %d", len(ri))
-	}
-}
-
-func TestDescribeDBInstance(t *testing.T) {
-	ts, tc := getTestClient(200, srDescribeDBInstanceResponse)
-	defer ts.Close()
-
-	id := "rds-try-test-db-1"
-	ri, err := tc.DescribeDBInstance(id)
-
-	if err != nil {
-		t.Errorf("[DescribeDBInstance] result error: %s", err.Error())
-	}
-	if *ri.DBInstanceIdentifier != id {
-		t.Errorf("DBInstanceIdentifier not match: %s/%s", *ri.DBInstanceIdentifier, id)
-	}
-}
-
-func TestCheckListTagsForResourceMessage(t *testing.T) {
-	ts, tc := getTestClient(200, srListTagsForResourceResponse)
-	defer ts.Close()
-
-	dbID := "rds-try-test"
-	var ti rds.DBInstance
-	ti.DBInstanceIdentifier = &dbID
-
-	ri, err := tc.checkListTagsForResource(&ti)
-
-	if err != nil {
-		t.Errorf("[checkListTagsFor] result error: %s", err.Error())
-	}
-	if !ri {
-		t.Error("tags count not match")
-	}
-}
-
-func TestModifyDBInstance(t *testing.T) {
-	ts, tc := getTestClient(200, srDescribeDBInstanceResponse)
-	defer ts.Close()
-
-	id := "rds-try-test-db-1"
-	ri, err := tc.DescribeDBInstance(id)
-
-	tsm, tcm := getTestClient(200, srModifyDBInstanceResponse)
-	defer tsm.Close()
-
-	rim, err := tcm.ModifyDBInstance(id, ri)
-
-	if err != nil {
-		t.Errorf("[ModifyDBInstance] result error: %s", err.Error())
-	}
-	if *rim.DBInstanceIdentifier != id {
-		t.Errorf("DBInstanceIdentifier not match: %s/%s", *rim.DBInstanceIdentifier, id)
-	}
-}
-
-func TestRebootDBInstance(t *testing.T) {
-	ts, tc := getTestClient(200, srRebootDBInstanceResponse)
-	defer ts.Close()
-
-	id := "rds-try-test-db-1"
-	ri, err := tc.RebootDBInstance(id)
-
-	if err != nil {
-		t.Errorf("[RebootDBInstance] result error: %s", err.Error())
-	}
-	if *ri.DBInstanceIdentifier != id {
-		t.Errorf("DBInstanceIdentifier not match: %s/%s", *ri.DBInstanceIdentifier, id)
-	}
-	if *ri.DBInstanceStatus != "rebooting" {
-		t.Errorf("DBInstanceStatus not match: %s/%s", *ri.DBInstanceStatus, "rebooting")
-	}
-}
-
-func TestDescribeDBSnapshots(t *testing.T) {
-	ts, tc := getTestClient(200, srDescribeDBSnapshotsResponse)
-	defer ts.Close()
-
-	input := &rds.DescribeDBSnapshotsInput{}
-	ri, err := tc.describeDBSnapshots(input)
-
-	if err != nil {
-		t.Errorf("[describeDBSnapshots] result error: %s", err.Error())
-	}
-	if len(ri) != 3 {
-		t.Errorf("DB Snapshot count not match: %d", len(ri))
-	}
-}
-
-func TestDescribeLatestDBSnapshot(t *testing.T) {
-	ts, tc := getTestClient(200, srDescribeDBSnapshotResponse)
-	defer ts.Close()
-
-	id := "rds-try-test-db-1"
-	ri, err := tc.DescribeLatestDBSnapshot(id)
-
-	if err != nil {
-		t.Errorf("[DescribeLatestDBSnapshot] result error: %s", err.Error())
-	}
-	if *ri.DBInstanceIdentifier != id {
-		t.Errorf("DBInstanceIdentifier not match: %s/%s", *ri.DBInstanceIdentifier, id)
-	}
-}
-
-func TestDescribeDBSnapshot(t *testing.T) {
-	ts, tc := getTestClient(200, srDescribeDBSnapshotResponse)
-	defer ts.Close()
-
-	id := "before-test-1"
-	ri, err := tc.DescribeDBSnapshot(id)
-
-	if err != nil {
-		t.Errorf("[DescribeDBSnapshot] result error: %s", err.Error())
-	}
-	if *ri.DBSnapshotIdentifier != id {
-		t.Errorf("DBSnapshotIdentifier not match: %s/%s", *ri.DBSnapshotIdentifier, id)
-	}
-}
-
-func TestDeleteDBInstance(t *testing.T) {
-	ts, tc := getTestClient(200, srDeleteDBInstanceResponse)
-	defer ts.Close()
-
-	id := "rds-try-test-db-1"
-	ri, err := tc.DeleteDBInstance(id)
-
-	if err != nil {
-		t.Errorf("[DeleteDBInstance] result error: %s", err.Error())
-	}
-	if *ri.DBInstanceIdentifier != id {
-		t.Errorf("DBInstanceIdentifier not match: %s/%s", *ri.DBInstanceIdentifier, id)
-	}
-	if *ri.DBInstanceStatus != "deleting" {
-		t.Errorf("DBInstanceStatus not match: %s/%s", *ri.DBInstanceStatus, "deleting")
-	}
-}
-
-func TestCreateDBSnapshot(t *testing.T) {
-	ts, tc := getTestClient(200, srCreateDBSnapshotResponse)
-	defer ts.Close()
-
-	id := "rds-try-test-db-1"
-	ri, err := tc.CreateDBSnapshot(id)
-
-	if err != nil {
-		t.Errorf("[CreateDBSnapshot] result error: %s", err.Error())
-	}
-	if *ri.DBInstanceIdentifier != id {
-		t.Errorf("DBInstanceIdentifier not match: %s/%s", *ri.DBInstanceIdentifier, id)
-	}
-}
-
-func TestDeleteDBSnapshot(t *testing.T) {
-	ts, tc := getTestClient(200, srDeleteDBSnapshotResponse)
-	defer ts.Close()
-
-	id := "before-test-1"
-	ri, err := tc.DeleteDBSnapshot(id)
-
-	if err != nil {
-		t.Errorf("[DeleteDBSnapshot] result error: %s", err.Error())
-	}
-	if *ri.DBSnapshotIdentifier != id {
-		t.Errorf("DBSnapshotIdentifier not match: %s/%s", *ri.DBSnapshotIdentifier, id)
-	}
-}
-
-func TestCheckPendingStatus(t *testing.T) {
-	ts, tc := getTestClient(200, srDescribeDBInstanceResponse)
-	defer ts.Close()
-
-	id := "rds-try-test-db-1"
-	ri, _ := tc.DescribeDBInstance(id)
-	state := tc.CheckPendingStatus(ri)
-
-	if state {
-		t.Error("CheckPendingStatus not match")
-	}
-}
-
-func TestRestoreDBInstanceFromDBSnapshot(t *testing.T) {
-	ts, tc := getTestClient(200, srDescribeDBInstanceResponse)
-	defer ts.Close()
-
-	id := "rds-try-test-db-1"
-	ri, _ := tc.DescribeDBInstance(id)
-
-	tss, tcs := getTestClient(200, srDescribeDBSnapshotResponse)
-	defer tss.Close()
-
-	ids := "before-test-1"
-	ris, _ := tcs.DescribeDBSnapshot(ids)
-
-	tsr, tcr := getTestClient(200, srRestoreDBInstanceFromDBSnapshotResponse)
-	defer tsr.Close()
-
-	class := "db.t1.micro"
-	az := false
-	args := &RestoreDBInstanceFromDBSnapshotArgs{
-		DBInstanceClass: class,
-		DBIdentifier:    id,
-		MultiAZ:         az,
-		Snapshot:        ris,
-		Instance:        ri,
-	}
-
-	rir, err := tcr.RestoreDBInstanceFromDBSnapshot(args)
-
-	if err != nil {
-		t.Errorf("[RestoreDBInstanceFromDBSnapshot] result error: %s", err.Error())
-	}
-	if *rir.DBInstanceIdentifier != id {
-		t.Errorf("DBInstanceIdentifier not match: %s/%s", *rir.DBInstanceIdentifier, id)
-	}
-	if *rir.DBInstanceStatus != "creating" {
-		t.Errorf("DBInstanceStatus not match: %s/%s", *rir.DBInstanceStatus, "creating")
-	}
-}
-
-func TestGetARNString(t *testing.T) {
-	ts, tc := getTestClient(200, "")
-	defer ts.Close()
-
-	id := "rds-try-test-db-1"
-	di := &rds.DBInstance{
-		DBInstanceIdentifier: &id,
-	}
-
-	arn := tc.getARNString(di)
-
-	if arn == "" {
-		t.Error("ARN string is nil")
-	}
-	if !strings.Contains(arn, id) {
-		t.Errorf("ARN string not contains id: %s", arn)
-	}
-}
-
-func TestGetSpecifyTags(t *testing.T) {
-	tags := getSpecifyTags()
-
-	if len(tags) != 2 {
-		t.Errorf("GetSpecifyTags count not match: %d", len(tags))
-	}
-	for _, i := range tags {
-		switch *i.Key {
-		case rtNameText, rtTimeText:
-			continue
-		default:
-			t.Errorf("GetSpecifyTags key name not match: %s", *i.Key)
-		}
-	}
-}
-
-func TestGetDbOpenValues(t *testing.T) {
-	eg := "mysql"
-	var port int64
-	port = 3306
-	addr := "rds-try-test-db.c6c2mntzugv0.us-west-2.rds.amazonaws.com"
-	ep := &rds.Endpoint{
-		Port:    &port,
-		Address: &addr,
-	}
-	q := []query.Query{
-		{
-			Name: "q1",
-			SQL:  "select * from account_id",
-		},
-		{
-			Name: "q2",
-			SQL:  "select count(*) from account_id",
-		},
-	}
-	args := &ExecuteSQLArgs{
-		Engine:   eg,
-		Endpoint: ep,
-		Queries:  q,
-	}
-
-	ts, tc := getTestClient(200, "")
-	defer ts.Close()
-
-	d, dn := tc.getDbOpenValues(args)
-
-	if d != eg {
-		t.Errorf("DB driver name not match: %s/%s", d, eg)
-	}
-	if !strings.Contains(dn, addr) {
-		t.Errorf("DB data source name not match: %s", dn)
-	}
-}
-
-func TestWriteCSVFile(t *testing.T) {
-	testName := utils.GetAppName() + "-test"
-	tempDir, _ := ioutil.TempDir("", testName)
-
-	tempFile, err := ioutil.TempFile(tempDir, utils.GetAppName()+"-test")
-	if err != nil {
-		t.Errorf("failed to create the temp file: %s", err.Error())
-	}
-	fStat, _ := tempFile.Stat()
-	fName := tempFile.Name()
-
-	tempFile.Sync()
-	tempFile.Close()
-	defer os.RemoveAll(tempDir)
-
-	db, _ := sql.Open("testdb", "")
-	defer db.Close()
-
-	sql := "select id, name, age from users"
-	columns := []string{"id", "name", "age", "created"}
-	result := `
-1,tim,20,2012-10-01 01:00:01
-2,joe,25,2012-10-02 02:00:02
-3,bob,30,2012-10-03 03:00:03
-`
-	testdb.StubQuery(sql, testdb.RowsFromCSVString(columns, result))
-
-	res, err := db.Query(sql)
-	args := &writeCSVFileArgs{
-		Rows:     res,
-		FileName: fStat.Name(),
-		Path:     tempDir,
-		Bom:      false,
-	}
-
-	csvState := writeCSVFile(args)
-
-	file, err := os.OpenFile(fName, os.O_RDONLY, 0777)
-	defer file.Close()
-
-	fStat, _ = file.Stat()
-
-	if !csvState {
-		t.Errorf("[writeCSVFile] result error: %s", err.Error())
-	}
-	if fStat.Size() <= 0 {
-		t.Errorf("csv file not out put: %d", fStat.Size())
-	}
-}
-
-// It takes 30 seconds every time
-func TestWaitForStatusAvailable(t *testing.T) {
-	ts, tc := getTestClient(200, srDescribeDBInstanceResponse)
-	defer ts.Close()
-
-	t.Log("It takes 30 seconds every time")
-	fmt.Println(" ### It takes 30 seconds every time")
-
-	id := "rds-try-test-db-1"
-	ri, _ := tc.DescribeDBInstance(id)
-	state := tc.WaitForStatusAvailable(ri)
-
-	if !<-state {
-		t.Error("WaitForStatusAvailable not match")
-	}
-}
+package command
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/rds"
+	testdb "github.com/erikstmartin/go-testdb"
+
+	"github.com/uchimanajet7/rds-try/config"
+	"github.com/uchimanajet7/rds-try/cron"
+	"github.com/uchimanajet7/rds-try/history"
+	"github.com/uchimanajet7/rds-try/notify"
+	"github.com/uchimanajet7/rds-try/query"
+	"github.com/uchimanajet7/rds-try/utils"
+)
+
+// return "httptest.Server" need call close !!
+// and Command into OutConfig.Root need call remove !!
+func getTestClient(code int, body string) (*httptest.Server, *Command) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(code)
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprintln(w, body)
+	}))
+
+	transport := &http.Transport{
+		Proxy: func(req *http.Request) (*url.URL, error) {
+			return url.Parse(server.URL)
+		},
+	}
+
+	httpClient := &http.Client{Transport: transport}
+
+	// Override endpoints
+	testRegion := "rds-try-test-1"
+	awsConf := aws.NewConfig()
+	awsConf = awsConf.WithCredentials(credentials.NewStaticCredentials("awsAccesskey1", "awsSecretKey2", ""))
+	awsConf = awsConf.WithRegion(testRegion)
+	awsConf = awsConf.WithEndpoint(server.URL)
+	awsConf = awsConf.WithHTTPClient(httpClient)
+
+	awsRds := rds.New(session.New(awsConf))
+
+	testName := utils.GetAppName() + "-test"
+	tempDir, _ := ioutil.TempDir("", testName)
+	defer os.RemoveAll(tempDir)
+
+	out := config.OutConfig{
+		Root: tempDir,
+		File: true,
+		Bom:  true,
+	}
+
+	rds := config.RDSConfig{
+		MultiAz: false,
+		DBId:    utils.GetFormatedDBDisplayName(testName),
+		Region:  testRegion,
+		User:    "test-admin",
+		Pass:    "pass-pass",
+		Type:    "db.m3.medium",
+	}
+
+	cmdTest := &Command{
+		OutConfig: out,
+		RDSConfig: rds,
+		RDSClient: awsRds,
+		ARNPrefix: "arn:aws:rds:" + testRegion + ":" + "123456789" + ":",
+	}
+
+	return server, cmdTest
+}
+
+func TestDescribeDBInstances(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBInstancesResponse)
+	defer ts.Close()
+
+	input := &rds.DescribeDBInstancesInput{}
+	ri, err := tc.describeDBInstances(input)
+
+	if err != nil {
+		t.Errorf("[describeDBInstances] result error: %s", err.Error())
+	}
+	if len(ri) != 3 {
+		t.Errorf("DB Instance count not match: %d", len(ri))
+	}
+}
+
+func TestDescribeDBInstance(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBInstanceResponse)
+	defer ts.Close()
+
+	id := "rds-try-test-db-1"
+	ri, err := tc.DescribeDBInstance(id)
+
+	if err != nil {
+		t.Errorf("[DescribeDBInstance] result error: %s", err.Error())
+	}
+	if *ri.DBInstanceIdentifier != id {
+		t.Errorf("DBInstanceIdentifier not match: %s/%s", *ri.DBInstanceIdentifier, id)
+	}
+}
+
+func TestCheckListTagsForResourceMessage(t *testing.T) {
+	ts, tc := getTestClient(200, srListTagsForResourceResponse)
+	defer ts.Close()
+
+	dbID := "rds-try-test"
+	var ti rds.DBInstance
+	ti.DBInstanceIdentifier = &dbID
+
+	ri, err := tc.checkListTagsForResource(&ti)
+
+	if err != nil {
+		t.Errorf("[checkListTagsFor] result error: %s", err.Error())
+	}
+	if !ri {
+		t.Error("tags count not match")
+	}
+}
+
+func TestGetCreatedTime(t *testing.T) {
+	ts, tc := getTestClient(200, srListTagsForResourceResponse)
+	defer ts.Close()
+
+	dbID := "rds-try-test"
+	var ti rds.DBInstance
+	ti.DBInstanceIdentifier = &dbID
+
+	ct, err := tc.GetCreatedTime(&ti)
+
+	if err != nil {
+		t.Errorf("[GetCreatedTime] result error: %s", err.Error())
+	}
+	expect := time.Date(2015, 2, 18, 10, 59, 5, 0, time.Local)
+	if !ct.Equal(expect) {
+		t.Errorf("created time not match: %s/%s", ct, expect)
+	}
+}
+
+func TestIsExpired(t *testing.T) {
+	ts, tc := getTestClient(200, srListTagsForResourceExpireResponse)
+	defer ts.Close()
+
+	dbID := "rds-try-test"
+	var ti rds.DBInstance
+	ti.DBInstanceIdentifier = &dbID
+
+	expired, err := tc.IsExpired(&ti)
+	if err != nil {
+		t.Errorf("[IsExpired] result error: %s", err.Error())
+	}
+	if !expired {
+		t.Error("expired not match")
+	}
+
+	// without rt_expire tag
+	tsn, tcn := getTestClient(200, srListTagsForResourceResponse)
+	defer tsn.Close()
+
+	expired, err = tcn.IsExpired(&ti)
+	if err != nil {
+		t.Errorf("[IsExpired] result error: %s", err.Error())
+	}
+	if expired {
+		t.Error("expired not match")
+	}
+}
+
+func TestModifyDBInstance(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBInstanceResponse)
+	defer ts.Close()
+
+	id := "rds-try-test-db-1"
+	ri, err := tc.DescribeDBInstance(id)
+
+	tsm, tcm := getTestClient(200, srModifyDBInstanceResponse)
+	defer tsm.Close()
+
+	args := &ModifyDBInstanceArgs{
+		DBIdentifier: id,
+		Instance:     ri,
+	}
+	rim, err := tcm.ModifyDBInstance(args)
+
+	if err != nil {
+		t.Errorf("[ModifyDBInstance] result error: %s", err.Error())
+	}
+	if *rim.DBInstanceIdentifier != id {
+		t.Errorf("DBInstanceIdentifier not match: %s/%s", *rim.DBInstanceIdentifier, id)
+	}
+}
+
+func TestRebootDBInstance(t *testing.T) {
+	ts, tc := getTestClient(200, srRebootDBInstanceResponse)
+	defer ts.Close()
+
+	id := "rds-try-test-db-1"
+	ri, err := tc.RebootDBInstance(id)
+
+	if err != nil {
+		t.Errorf("[RebootDBInstance] result error: %s", err.Error())
+	}
+	if *ri.DBInstanceIdentifier != id {
+		t.Errorf("DBInstanceIdentifier not match: %s/%s", *ri.DBInstanceIdentifier, id)
+	}
+	if *ri.DBInstanceStatus != "rebooting" {
+		t.Errorf("DBInstanceStatus not match: %s/%s", *ri.DBInstanceStatus, "rebooting")
+	}
+}
+
+func TestDescribeDBSnapshots(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBSnapshotsResponse)
+	defer ts.Close()
+
+	input := &rds.DescribeDBSnapshotsInput{}
+	ri, err := tc.describeDBSnapshots(input)
+
+	if err != nil {
+		t.Errorf("[describeDBSnapshots] result error: %s", err.Error())
+	}
+	if len(ri) != 3 {
+		t.Errorf("DB Snapshot count not match: %d", len(ri))
+	}
+}
+
+func TestDescribeLatestDBSnapshot(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBSnapshotResponse)
+	defer ts.Close()
+
+	id := "rds-try-test-db-1"
+	ri, err := tc.DescribeLatestDBSnapshot(id)
+
+	if err != nil {
+		t.Errorf("[DescribeLatestDBSnapshot] result error: %s", err.Error())
+	}
+	if *ri.DBInstanceIdentifier != id {
+		t.Errorf("DBInstanceIdentifier not match: %s/%s", *ri.DBInstanceIdentifier, id)
+	}
+}
+
+func TestDescribeLatestSharedDBSnapshot(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeSharedDBSnapshotsResponse)
+	defer ts.Close()
+
+	id := "rds-try-test-db-1"
+	ri, err := tc.DescribeLatestSharedDBSnapshot(id)
+
+	if err != nil {
+		t.Errorf("[DescribeLatestSharedDBSnapshot] result error: %s", err.Error())
+	}
+	arn := "arn:aws:rds:us-west-2:210987654321:snapshot:shared-test-2"
+	if *ri.DBSnapshotArn != arn {
+		t.Errorf("DBSnapshotArn not match: %s/%s", *ri.DBSnapshotArn, arn)
+	}
+	if tc.getARNString(ri) != arn {
+		t.Errorf("ARN string not match: %s/%s", tc.getARNString(ri), arn)
+	}
+
+	_, err = tc.DescribeLatestSharedDBSnapshot("rds-try-test-db-9")
+	if err != ErrSnapshotNotFound {
+		t.Errorf("[DescribeLatestSharedDBSnapshot] not found error not match: %v", err)
+	}
+}
+
+func TestDescribeEncryptedSharedDBSnapshot(t *testing.T) {
+	ts, tc := getTestClient(200, strings.Replace(srDescribeSharedDBSnapshotsResponse, "<Encrypted>false</Encrypted>", "<Encrypted>true</Encrypted>", -1))
+	defer ts.Close()
+
+	// checked before copy and restore
+	ec := &EsCommand{Command: tc}
+	ec.RDSConfig.DBId = "rds-try-test-db-1"
+	if _, err := ec.describeSharedDBSnapshot(); err != ErrKmsKeyNotFound {
+		t.Errorf("[describeSharedDBSnapshot] error not match: %v", err)
+	}
+
+	ec.RDSConfig.KmsKeyID = "arn:aws:kms:us-west-2:123456789012:key/rds-try-test"
+	snapShot, err := ec.describeSharedDBSnapshot()
+	if err != nil || !isEncryptedDBSnapshot(snapShot) {
+		t.Errorf("[describeSharedDBSnapshot] result error: %v", err)
+	}
+}
+
+func TestGetSharedDBInstance(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeSharedDBSnapshotsResponse)
+	defer ts.Close()
+
+	arn := "arn:aws:rds:us-west-2:210987654321:snapshot:shared-test-1"
+	ri, err := tc.DescribeSharedDBSnapshot(arn)
+	if err != nil {
+		t.Errorf("[DescribeSharedDBSnapshot] result error: %s", err.Error())
+	}
+
+	di := getSharedDBInstance(ri)
+	if di.DBInstanceClass != nil {
+		t.Errorf("DBInstanceClass must be nil: %s", *di.DBInstanceClass)
+	}
+	if di.DBSubnetGroup != nil || len(di.DBParameterGroups) > 0 || len(di.VpcSecurityGroups) > 0 {
+		t.Errorf("network settings must be empty: %+v", di)
+	}
+	if *di.StorageType != "gp2" {
+		t.Errorf("StorageType not match: %s/%s", *di.StorageType, "gp2")
+	}
+}
+
+func TestDescribeDBSnapshot(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBSnapshotResponse)
+	defer ts.Close()
+
+	id := "before-test-1"
+	ri, err := tc.DescribeDBSnapshot(id)
+
+	if err != nil {
+		t.Errorf("[DescribeDBSnapshot] result error: %s", err.Error())
+	}
+	if *ri.DBSnapshotIdentifier != id {
+		t.Errorf("DBSnapshotIdentifier not match: %s/%s", *ri.DBSnapshotIdentifier, id)
+	}
+}
+
+func TestDeleteDBInstance(t *testing.T) {
+	ts, tc := getTestClient(200, srDeleteDBInstanceResponse)
+	defer ts.Close()
+
+	id := "rds-try-test-db-1"
+	ri, err := tc.DeleteDBInstance(id)
+
+	if err != nil {
+		t.Errorf("[DeleteDBInstance] result error: %s", err.Error())
+	}
+	if *ri.DBInstanceIdentifier != id {
+		t.Errorf("DBInstanceIdentifier not match: %s/%s", *ri.DBInstanceIdentifier, id)
+	}
+	if *ri.DBInstanceStatus != "deleting" {
+		t.Errorf("DBInstanceStatus not match: %s/%s", *ri.DBInstanceStatus, "deleting")
+	}
+}
+
+func TestCreateDBSnapshot(t *testing.T) {
+	ts, tc := getTestClient(200, srCreateDBSnapshotResponse)
+	defer ts.Close()
+
+	id := "rds-try-test-db-1"
+	ri, err := tc.CreateDBSnapshot(id)
+
+	if err != nil {
+		t.Errorf("[CreateDBSnapshot] result error: %s", err.Error())
+	}
+	if *ri.DBInstanceIdentifier != id {
+		t.Errorf("DBInstanceIdentifier not match: %s/%s", *ri.DBInstanceIdentifier, id)
+	}
+}
+
+func TestCopyDBSnapshot(t *testing.T) {
+	ts, tc := getTestClient(200, srCopyDBSnapshotResponse)
+	defer ts.Close()
+
+	src := "arn:aws:rds:ap-northeast-1:123456789012:snapshot:before-test-1"
+	ri, err := tc.CopyDBSnapshot(&CopyDBSnapshotArgs{
+		SourceSnapshotIdentifier: src,
+		DBIdentifier:             "rds-try-test-db-1",
+		KmsKeyID:                 "arn:aws:kms:us-east-1:123456789012:key/test-key",
+		SourceRegion:             "ap-northeast-1",
+	})
+
+	if err != nil {
+		t.Errorf("[CopyDBSnapshot] result error: %s", err.Error())
+	}
+	if *ri.DBSnapshotIdentifier != "after-copy-1" {
+		t.Errorf("DBSnapshotIdentifier not match: %s/%s", *ri.DBSnapshotIdentifier, "after-copy-1")
+	}
+}
+
+func TestGetRegionCommands(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	if cmds := tc.GetRegionCommands(); len(cmds) != 1 {
+		t.Errorf("region commands count not match: %d/%d", len(cmds), 1)
+	}
+
+	tc.CopyCommand = &Command{}
+	cmds := tc.GetRegionCommands()
+	if len(cmds) != 2 {
+		t.Errorf("region commands count not match: %d/%d", len(cmds), 2)
+	}
+	if cmds[1] != tc.CopyCommand {
+		t.Errorf("copy command not match")
+	}
+}
+
+func TestDeleteDBSnapshot(t *testing.T) {
+	ts, tc := getTestClient(200, srDeleteDBSnapshotResponse)
+	defer ts.Close()
+
+	id := "before-test-1"
+	ri, err := tc.DeleteDBSnapshot(id)
+
+	if err != nil {
+		t.Errorf("[DeleteDBSnapshot] result error: %s", err.Error())
+	}
+	if *ri.DBSnapshotIdentifier != id {
+		t.Errorf("DBSnapshotIdentifier not match: %s/%s", *ri.DBSnapshotIdentifier, id)
+	}
+}
+
+func TestCheckPendingReboot(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	name := "rds-try-test-group"
+	status := "in-sync"
+	db := &rds.DBInstance{
+		DBParameterGroups: []*rds.DBParameterGroupStatus{
+			{DBParameterGroupName: &name, ParameterApplyStatus: &status},
+		},
+	}
+
+	if tc.CheckPendingReboot(db) {
+		t.Errorf("CheckPendingReboot not match: %s", status)
+	}
+
+	if isParameterApplying(db) {
+		t.Errorf("isParameterApplying not match: %s", status)
+	}
+
+	// "applying" is waited, and rebooted if it is not in-sync after that
+	status = "applying"
+	if !isParameterApplying(db) {
+		t.Errorf("isParameterApplying not match: %s", status)
+	}
+	if !tc.CheckPendingReboot(db) {
+		t.Errorf("CheckPendingReboot not match: %s", status)
+	}
+
+	status = "pending-reboot"
+	if !tc.CheckPendingReboot(db) {
+		t.Errorf("CheckPendingReboot not match: %s", status)
+	}
+}
+
+func TestCheckPendingStatus(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBInstanceResponse)
+	defer ts.Close()
+
+	id := "rds-try-test-db-1"
+	ri, _ := tc.DescribeDBInstance(id)
+	state := tc.CheckPendingStatus(ri)
+
+	if state {
+		t.Error("CheckPendingStatus not match")
+	}
+}
+
+func TestRestoreDBInstanceFromDBSnapshot(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBInstanceResponse)
+	defer ts.Close()
+
+	id := "rds-try-test-db-1"
+	ri, _ := tc.DescribeDBInstance(id)
+
+	tss, tcs := getTestClient(200, srDescribeDBSnapshotResponse)
+	defer tss.Close()
+
+	ids := "before-test-1"
+	ris, _ := tcs.DescribeDBSnapshot(ids)
+
+	tsr, tcr := getTestClient(200, srRestoreDBInstanceFromDBSnapshotResponse)
+	defer tsr.Close()
+
+	class := "db.t1.micro"
+	az := false
+	args := &RestoreDBInstanceFromDBSnapshotArgs{
+		DBInstanceClass: class,
+		DBIdentifier:    id,
+		MultiAZ:         az,
+		Snapshot:        ris,
+		Instance:        ri,
+	}
+
+	rir, err := tcr.RestoreDBInstanceFromDBSnapshot(args)
+
+	if err != nil {
+		t.Errorf("[RestoreDBInstanceFromDBSnapshot] result error: %s", err.Error())
+	}
+	if *rir.DBInstanceIdentifier != id {
+		t.Errorf("DBInstanceIdentifier not match: %s/%s", *rir.DBInstanceIdentifier, id)
+	}
+	if *rir.DBInstanceStatus != "creating" {
+		t.Errorf("DBInstanceStatus not match: %s/%s", *rir.DBInstanceStatus, "creating")
+	}
+}
+
+func TestGetARNString(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	id := "rds-try-test-db-1"
+	di := &rds.DBInstance{
+		DBInstanceIdentifier: &id,
+	}
+
+	arn := tc.getARNString(di)
+
+	if arn == "" {
+		t.Error("ARN string is nil")
+	}
+	if !strings.Contains(arn, id) {
+		t.Errorf("ARN string not contains id: %s", arn)
+	}
+}
+
+func TestCreateDBInstanceReadReplica(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBInstanceResponse)
+	defer ts.Close()
+
+	source := "rds-try-test-db-1"
+	ri, _ := tc.DescribeDBInstance(source)
+
+	tsr, tcr := getTestClient(200, srCreateDBInstanceReadReplicaResponse)
+	defer tsr.Close()
+
+	id := "rds-try-test-db-1-replica"
+	args := &CreateDBInstanceReadReplicaArgs{
+		DBInstanceClass: "db.t1.micro",
+		DBIdentifier:    id,
+		Instance:        ri,
+	}
+
+	rir, err := tcr.CreateDBInstanceReadReplica(args)
+
+	if err != nil {
+		t.Errorf("[CreateDBInstanceReadReplica] result error: %s", err.Error())
+	}
+	if *rir.DBInstanceIdentifier != id {
+		t.Errorf("DBInstanceIdentifier not match: %s/%s", *rir.DBInstanceIdentifier, id)
+	}
+	if text := getReplicaText(rir); text != " (read replica of "+source+")" {
+		t.Errorf("replica text not match: %s", text)
+	}
+	if text := getReplicaText(ri); text != "" {
+		t.Errorf("replica text must be empty: %s", text)
+	}
+}
+
+func TestRunDetailsReplicaOption(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	// snapshot and migration can not be used with read replica
+	for _, ec := range []*EsCommand{
+		{Command: tc, OptReplica: true, OptSnap: true},
+		{Command: tc, OptReplica: true, OptMigration: "rds-try.migration"},
+	} {
+		if err := ec.runDetails(nil); err != ErrReplicaOptionNotSupported {
+			t.Errorf("[runDetails] error not match: %v", err)
+		}
+	}
+}
+
+func TestRunDetailsSharedNetwork(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	// checked before any AWS call
+	tc.RDSConfig.SnapshotType = SharedSnapshotType
+	tc.RDSConfig.SubnetGroup = "rds-try-test-subnet"
+	ec := &EsCommand{Command: tc}
+	if err := ec.runDetails(nil); err != ErrSharedNetworkNotFound {
+		t.Errorf("[runDetails] error not match: %v", err)
+	}
+
+	tc.RDSConfig.SubnetGroup = ""
+	tc.RDSConfig.SecurityGroups = []string{"sg-rds-try-test"}
+	if err := ec.runDetails(nil); err != ErrSharedNetworkNotFound {
+		t.Errorf("[runDetails] error not match: %v", err)
+	}
+}
+
+func TestGetARNStringCluster(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	id := "rds-try-test-cluster-1"
+	arn := tc.getARNString(&rds.DBCluster{DBClusterIdentifier: &id})
+	if arn != tc.ARNPrefix+"cluster:"+id {
+		t.Errorf("ARN string not match: %s", arn)
+	}
+
+	sid := "before-cluster-test-1"
+	arn = tc.getARNString(&rds.DBClusterSnapshot{DBClusterSnapshotIdentifier: &sid})
+	if arn != tc.ARNPrefix+"cluster-snapshot:"+sid {
+		t.Errorf("ARN string not match: %s", arn)
+	}
+}
+
+func TestGetSpecifyTags(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	tags := tc.getSpecifyTags()
+
+	if len(tags) != 2 {
+		t.Errorf("GetSpecifyTags count not match: %d", len(tags))
+	}
+	for _, i := range tags {
+		switch *i.Key {
+		case rtNameText, rtTimeText:
+			continue
+		default:
+			t.Errorf("GetSpecifyTags key name not match: %s", *i.Key)
+		}
+	}
+
+	// rt_expire is set by TTL
+	tc.TTL = 6 * time.Hour
+	tags = tc.getSpecifyTags()
+
+	if len(tags) != 3 {
+		t.Errorf("GetSpecifyTags count not match: %d", len(tags))
+	}
+	if *tags[2].Key != rtExpireText {
+		t.Errorf("GetSpecifyTags key name not match: %s", *tags[2].Key)
+	}
+	expire, err := utils.ParseTagTime(*tags[2].Value)
+	if err != nil || expire.Before(time.Now().Add(5*time.Hour)) {
+		t.Errorf("GetSpecifyTags expire time not match: %s", *tags[2].Value)
+	}
+}
+
+func TestGetDbOpenValues(t *testing.T) {
+	eg := "mysql"
+	var port int64
+	port = 3306
+	addr := "rds-try-test-db.c6c2mntzugv0.us-west-2.rds.amazonaws.com"
+	ep := &rds.Endpoint{
+		Port:    &port,
+		Address: &addr,
+	}
+	q := []query.Query{
+		{
+			Name: "q1",
+			SQL:  "select * from account_id",
+		},
+		{
+			Name: "q2",
+			SQL:  "select count(*) from account_id",
+		},
+	}
+	args := &ExecuteSQLArgs{
+		Engine:   eg,
+		Endpoint: ep,
+		Queries:  q,
+	}
+
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	d, dn := tc.getDbOpenValues(args)
+
+	if d != eg {
+		t.Errorf("DB driver name not match: %s/%s", d, eg)
+	}
+	if !strings.Contains(dn, addr) {
+		t.Errorf("DB data source name not match: %s", dn)
+	}
+
+	// Aurora is opened as mysql
+	args.Engine = "aurora"
+	d, dn = tc.getDbOpenValues(args)
+
+	if d != eg {
+		t.Errorf("DB driver name not match: %s/%s", d, eg)
+	}
+	if !strings.Contains(dn, addr) {
+		t.Errorf("DB data source name not match: %s", dn)
+	}
+
+	// MariaDB is opened as mysql
+	args.Engine = "mariadb"
+	d, dn = tc.getDbOpenValues(args)
+
+	if d != eg {
+		t.Errorf("DB driver name not match: %s/%s", d, eg)
+	}
+	if !strings.Contains(dn, addr) {
+		t.Errorf("DB data source name not match: %s", dn)
+	}
+
+	// unknown engine has no driver
+	args.Engine = "db2-se"
+	d, dn = tc.getDbOpenValues(args)
+
+	if d != "" || dn != "" {
+		t.Errorf("DB driver name not match: %s/%s", d, dn)
+	}
+
+	// postgres connects to "postgres" database with sslmode "require" if not specified
+	port = 5432
+	args.Engine = "postgres"
+	tc.RDSConfig.Pass = "p@ss/word"
+	d, dn = tc.getDbOpenValues(args)
+
+	if d != "postgres" {
+		t.Errorf("DB driver name not match: %s/%s", d, "postgres")
+	}
+	ed := "postgres://" + tc.RDSConfig.User + ":p%40ss%2Fword@" + addr + ":5432/postgres?sslmode=require"
+	if dn != ed {
+		t.Errorf("DB data source name not match: %s/%s", dn, ed)
+	}
+
+	tc.RDSConfig.DBName = "testdb"
+	tc.RDSConfig.SSLMode = "verify-full"
+	_, dn = tc.getDbOpenValues(args)
+
+	if !strings.HasSuffix(dn, ":5432/testdb?sslmode=verify-full") {
+		t.Errorf("DB data source name not match: %s", dn)
+	}
+
+	// sql server selects the database by "database" parameter
+	port = 1433
+	args.Engine = "sqlserver-se"
+	tc.RDSConfig.SSLMode = ""
+	d, dn = tc.getDbOpenValues(args)
+
+	if d != "sqlserver" {
+		t.Errorf("DB driver name not match: %s/%s", d, "sqlserver")
+	}
+	ed = "sqlserver://" + tc.RDSConfig.User + ":p%40ss%2Fword@" + addr + ":1433?database=testdb"
+	if dn != ed {
+		t.Errorf("DB data source name not match: %s/%s", dn, ed)
+	}
+
+	tc.RDSConfig.DBName = ""
+	_, dn = tc.getDbOpenValues(args)
+
+	if strings.Contains(dn, "database=") {
+		t.Errorf("DB data source name not match: %s", dn)
+	}
+
+	// oracle connects to SID "ORCL" if service name and SID are not specified
+	port = 1521
+	args.Engine = "oracle-ee"
+	d, dn = tc.getDbOpenValues(args)
+
+	if d != "oracle" {
+		t.Errorf("DB driver name not match: %s/%s", d, "oracle")
+	}
+	if !strings.HasSuffix(dn, addr+":1521/?SID=ORCL") {
+		t.Errorf("DB data source name not match: %s", dn)
+	}
+
+	tc.RDSConfig.SID = "TEST"
+	_, dn = tc.getDbOpenValues(args)
+
+	if !strings.HasSuffix(dn, addr+":1521/?SID=TEST") {
+		t.Errorf("DB data source name not match: %s", dn)
+	}
+
+	// service name is used rather than SID
+	tc.RDSConfig.ServiceName = "TESTSVC"
+	_, dn = tc.getDbOpenValues(args)
+
+	if !strings.HasSuffix(dn, addr+":1521/TESTSVC") {
+		t.Errorf("DB data source name not match: %s", dn)
+	}
+}
+
+func TestGetDBEngine(t *testing.T) {
+	// mariadb and aurora mysql are opened as mysql
+	for _, name := range []string{"MySQL", "mariadb", "aurora", "aurora-mysql"} {
+		dbEngine, err := getDBEngine(name)
+		if err != nil {
+			t.Fatalf("DB engine not found: %s", name)
+		}
+		if dbEngine.Driver != "mysql" || !dbEngine.Mask {
+			t.Errorf("DB engine not match: %s/%s", name, dbEngine.Driver)
+		}
+	}
+
+	dbEngine, err := getDBEngine("aurora-postgresql")
+	if err != nil || dbEngine.Driver != "postgres" {
+		t.Errorf("DB engine not match: %s", "aurora-postgresql")
+	}
+
+	dbEngine, err = getDBEngine("sqlserver-web")
+	if err != nil || dbEngine.Driver != "sqlserver" || !dbEngine.SingleSession {
+		t.Errorf("DB engine not match: %s", "sqlserver-web")
+	}
+
+	_, err = getDBEngine("db2-se")
+	if err != ErrEngineNotSupported {
+		t.Errorf("DB engine error not match: %v", err)
+	}
+}
+
+func TestCheckDBEngine(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	if err := tc.checkDBEngine(nil, false); err != ErrEngineNotSupported {
+		t.Errorf("DB engine error not match: %v", err)
+	}
+
+	eg := "mariadb"
+	if err := tc.checkDBEngine(&eg, true); err != nil {
+		t.Errorf("DB engine error not match: %v", err)
+	}
+
+	// the masking sql is written for mysql
+	eg = "postgres"
+	if err := tc.checkDBEngine(&eg, false); err != nil {
+		t.Errorf("DB engine error not match: %v", err)
+	}
+	if err := tc.checkDBEngine(&eg, true); err != ErrMaskNotSupported {
+		t.Errorf("DB engine error not match: %v", err)
+	}
+
+	eg = "db2-ae"
+	if err := tc.checkDBEngine(&eg, false); err != ErrEngineNotSupported {
+		t.Errorf("DB engine error not match: %v", err)
+	}
+}
+
+func TestCheckSSLMode(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	for _, mode := range []string{"", "disable", "require", "verify-ca", "verify-full"} {
+		tc.RDSConfig.SSLMode = mode
+		if err := tc.CheckSSLMode(); err != nil {
+			t.Errorf("ssl mode error not match: %s", mode)
+		}
+	}
+
+	tc.RDSConfig.SSLMode = "prefer"
+	if err := tc.CheckSSLMode(); err != ErrSSLModeNotSupported {
+		t.Errorf("ssl mode error not match: %v", err)
+	}
+}
+
+func TestCheckSSLSettings(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	eg := "sqlserver-ee"
+	tc.RDSConfig.SSLMode = "verify-full"
+	tc.RDSConfig.SSLCA = "rds-ca.pem"
+	tc.RDSConfig.SSLServerName = "rds-try-test-db.example.com"
+	if err := tc.checkDBEngine(&eg, false); err != nil {
+		t.Errorf("ssl settings error not match: %v", err)
+	}
+
+	// the server name of postgres is always the host
+	eg = "postgres"
+	if err := tc.checkDBEngine(&eg, false); err != ErrSSLServerNameNotSupported {
+		t.Errorf("ssl settings error not match: %v", err)
+	}
+
+	// sql server always verifies the server name with the certificate
+	eg = "sqlserver-ee"
+	tc.RDSConfig.SSLMode = "verify-ca"
+	if err := tc.checkDBEngine(&eg, false); err != ErrSSLModeNotSupportedByEngine {
+		t.Errorf("ssl settings error not match: %v", err)
+	}
+
+	// oracle verifies the certificate only by the wallet
+	eg = "oracle-ee"
+	tc.RDSConfig.SSLMode = "require"
+	tc.RDSConfig.SSLServerName = ""
+	if err := tc.checkDBEngine(&eg, false); err != ErrSSLCANotSupported {
+		t.Errorf("ssl settings error not match: %v", err)
+	}
+	tc.RDSConfig.SSLCA = ""
+	if err := tc.checkDBEngine(&eg, false); err != nil {
+		t.Errorf("ssl settings error not match: %v", err)
+	}
+}
+
+func TestGetTLSDataSourceName(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	addr := "rds-try-test-db.c6c2mntzugv0.us-west-2.rds.amazonaws.com"
+	var port int64 = 1433
+	ep := &rds.Endpoint{Address: &addr, Port: &port}
+
+	tc.RDSConfig.SSLMode = "require"
+	dn := tc.getSQLServerDataSourceName(ep)
+	if !strings.HasSuffix(dn, "?TrustServerCertificate=true&encrypt=true") {
+		t.Errorf("DB data source name not match: %s", dn)
+	}
+
+	tc.RDSConfig.SSLMode = "verify-full"
+	tc.RDSConfig.SSLCA = "rds-ca.pem"
+	tc.RDSConfig.SSLServerName = "rds-try-test-db.example.com"
+	dn = tc.getSQLServerDataSourceName(ep)
+	if !strings.HasSuffix(dn, "?TrustServerCertificate=false&certificate=rds-ca.pem&encrypt=true&hostNameInCertificate=rds-try-test-db.example.com") {
+		t.Errorf("DB data source name not match: %s", dn)
+	}
+
+	tc.RDSConfig.SSLMode = "disable"
+	dn = tc.getSQLServerDataSourceName(ep)
+	if !strings.HasSuffix(dn, "?encrypt=disable") {
+		t.Errorf("DB data source name not match: %s", dn)
+	}
+
+	// oracle is encrypted without verification
+	port = 2484
+	tc.RDSConfig.SSLMode = "require"
+	dn = tc.getOracleDataSourceName(ep)
+	if !strings.HasSuffix(dn, ":2484/?SID=ORCL&SSL=true&SSL+VERIFY=false") {
+		t.Errorf("DB data source name not match: %s", dn)
+	}
+}
+
+func TestGetTLSConfig(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	// the certificate of tls test server is used as ca bundle
+	tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer tlsServer.Close()
+
+	tempDir, _ := ioutil.TempDir("", utils.GetAppName()+"-test")
+	defer os.RemoveAll(tempDir)
+	caFile := tempDir + "/rds-ca.pem"
+	caPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw})
+	ioutil.WriteFile(caFile, caPem, 0644)
+
+	addr := "rds-try-test-db.c6c2mntzugv0.us-west-2.rds.amazonaws.com"
+	var port int64 = 3306
+	ep := &rds.Endpoint{Address: &addr, Port: &port}
+
+	// not encrypted if not specified
+	if strings.Contains(tc.getMySQLDataSourceName(ep), "tls=") {
+		t.Errorf("DB data source name not match: %s", tc.getMySQLDataSourceName(ep))
+	}
+
+	tc.RDSConfig.SSLMode = "require"
+	tlsConfig, err := tc.getTLSConfig(ep)
+	if err != nil || !tlsConfig.InsecureSkipVerify {
+		t.Errorf("tls config not match: %+v", tlsConfig)
+	}
+	if !strings.HasSuffix(tc.getMySQLDataSourceName(ep), "/?tls=rds-try-"+tc.Name) {
+		t.Errorf("DB data source name not match: %s", tc.getMySQLDataSourceName(ep))
+	}
+	if err := tc.registerMySQLTLSConfig(ep); err != nil {
+		t.Errorf("register tls config error: %s", err.Error())
+	}
+
+	// the server name is the endpoint address if not specified
+	tc.RDSConfig.SSLMode = "verify-full"
+	tc.RDSConfig.SSLCA = caFile
+	tlsConfig, err = tc.getTLSConfig(ep)
+	if err != nil {
+		t.Fatalf("tls config error: %s", err.Error())
+	}
+	if tlsConfig.InsecureSkipVerify || tlsConfig.ServerName != addr || tlsConfig.RootCAs == nil {
+		t.Errorf("tls config not match: %+v", tlsConfig)
+	}
+
+	tc.RDSConfig.SSLServerName = "rds-try-test-db.example.com"
+	tlsConfig, _ = tc.getTLSConfig(ep)
+	if tlsConfig.ServerName != tc.RDSConfig.SSLServerName {
+		t.Errorf("tls config server name not match: %s", tlsConfig.ServerName)
+	}
+
+	// only the certificate chain is verified
+	tc.RDSConfig.SSLMode = "verify-ca"
+	tlsConfig, _ = tc.getTLSConfig(ep)
+	if !tlsConfig.InsecureSkipVerify || tlsConfig.VerifyPeerCertificate == nil {
+		t.Fatalf("tls config not match: %+v", tlsConfig)
+	}
+	if err := tlsConfig.VerifyPeerCertificate([][]byte{tlsServer.Certificate().Raw}, nil); err != nil {
+		t.Errorf("verify certificate error: %s", err.Error())
+	}
+
+	// postgres uses ca bundle as "sslrootcert"
+	port = 5432
+	if !strings.Contains(tc.getPostgresDataSourceName(ep), "sslmode=verify-ca&sslrootcert=") {
+		t.Errorf("DB data source name not match: %s", tc.getPostgresDataSourceName(ep))
+	}
+
+	// ca bundle without certificate
+	ioutil.WriteFile(caFile, []byte("not certificate"), 0644)
+	if _, err := tc.getTLSConfig(ep); err != ErrSSLCertificateNotFound {
+		t.Errorf("tls config error not match: %v", err)
+	}
+}
+
+func TestGetBatches(t *testing.T) {
+	sql := "CREATE TABLE #tmp (id int)\ngo\nINSERT INTO #tmp VALUES (1)\r\n  GO 2\r\nSELECT * FROM #tmp\nGO\n"
+
+	batches := getBatches("sqlserver-ee", sql)
+	if len(batches) != 4 {
+		t.Fatalf("batch count not match: %d", len(batches))
+	}
+	if batches[0] != "CREATE TABLE #tmp (id int)" {
+		t.Errorf("batch not match: %s", batches[0])
+	}
+	// repeated by the count
+	if batches[1] != "INSERT INTO #tmp VALUES (1)" || batches[2] != batches[1] {
+		t.Errorf("batch not match: %s/%s", batches[1], batches[2])
+	}
+	if batches[3] != "SELECT * FROM #tmp" {
+		t.Errorf("batch not match: %s", batches[3])
+	}
+
+	// "GO" in the statement is not the separator
+	sql = "SELECT 'GO' AS go_text FROM category"
+	batches = getBatches("sqlserver-ee", sql)
+	if len(batches) != 1 || batches[0] != sql {
+		t.Errorf("batch not match: %v", batches)
+	}
+
+	// other engines are not split
+	sql = "SELECT 1\nGO\nSELECT 2"
+	batches = getBatches("mysql", sql)
+	if len(batches) != 1 || batches[0] != sql {
+		t.Errorf("batch not match: %v", batches)
+	}
+
+	// the trailing semicolon of oracle is removed
+	batches = getBatches("oracle-ee", "SELECT * FROM category;\n")
+	if len(batches) != 1 || batches[0] != "SELECT * FROM category" {
+		t.Errorf("batch not match: %v", batches)
+	}
+	batches = getBatches("oracle-se1", "SELECT CASE WHEN id > 1 THEN 1 ELSE 0 END;")
+	if batches[0] != "SELECT CASE WHEN id > 1 THEN 1 ELSE 0 END" {
+		t.Errorf("batch not match: %s", batches[0])
+	}
+
+	// the semicolon of pl/sql block is kept, and "/" is removed
+	sql = "BEGIN\n  UPDATE category SET name = 'test';\nEND;"
+	batches = getBatches("oracle-ee", sql+"\n/\n")
+	if batches[0] != sql {
+		t.Errorf("batch not match: %s", batches[0])
+	}
+	sql = "CREATE OR REPLACE PROCEDURE clear_category IS\nBEGIN\n  DELETE FROM category;\nEND clear_category;"
+	batches = getBatches("oracle-ee", sql)
+	if batches[0] != sql {
+		t.Errorf("batch not match: %s", batches[0])
+	}
+}
+
+func TestWriteCSVFile(t *testing.T) {
+	testName := utils.GetAppName() + "-test"
+	tempDir, _ := ioutil.TempDir("", testName)
+
+	tempFile, err := ioutil.TempFile(tempDir, utils.GetAppName()+"-test")
+	if err != nil {
+		t.Errorf("failed to create the temp file: %s", err.Error())
+	}
+	fStat, _ := tempFile.Stat()
+	fName := tempFile.Name()
+
+	tempFile.Sync()
+	tempFile.Close()
+	defer os.RemoveAll(tempDir)
+
+	db, _ := sql.Open("testdb", "")
+	defer db.Close()
+
+	sql := "select id, name, age from users"
+	columns := []string{"id", "name", "age", "created"}
+	result := `
+1,tim,20,2012-10-01 01:00:01
+2,joe,25,2012-10-02 02:00:02
+3,bob,30,2012-10-03 03:00:03
+`
+	testdb.StubQuery(sql, testdb.RowsFromCSVString(columns, result))
+
+	res, err := db.Query(sql)
+	args := &writeCSVFileArgs{
+		Rows:     res,
+		FileName: fStat.Name(),
+		Path:     tempDir,
+		Bom:      false,
+	}
+
+	csvResult, err := writeCSVFile(args)
+
+	file, err := os.OpenFile(fName, os.O_RDONLY, 0777)
+	defer file.Close()
+
+	fStat, _ = file.Stat()
+
+	if csvResult == nil {
+		t.Fatalf("[writeCSVFile] result error: %v", err)
+	}
+	if csvResult.Rows != 3 {
+		t.Errorf("csv rows not match: %d", csvResult.Rows)
+	}
+	if csvResult.Path != fName {
+		t.Errorf("csv path not match: %s", csvResult.Path)
+	}
+	if fStat.Size() <= 0 {
+		t.Errorf("csv file not out put: %d", fStat.Size())
+	}
+
+	// the checksum is the same whether or not written to file
+	res, _ = db.Query(sql)
+	countResult, err := countRows(res)
+	if err != nil {
+		t.Fatalf("[countRows] result error: %s", err.Error())
+	}
+	if countResult.Rows != 3 {
+		t.Errorf("count rows not match: %d", countResult.Rows)
+	}
+	if countResult.Checksum != csvResult.Checksum || len(countResult.Checksum) != 64 {
+		t.Errorf("checksum not match: %s/%s", countResult.Checksum, csvResult.Checksum)
+	}
+
+	// different data is different checksum
+	otherSQL := "select id, name, age from other_users"
+	testdb.StubQuery(otherSQL, testdb.RowsFromCSVString(columns, strings.Replace(result, "bob", "bo", 1)))
+	res, _ = db.Query(otherSQL)
+	otherResult, _ := countRows(res)
+	if otherResult == nil || otherResult.Checksum == csvResult.Checksum {
+		t.Error("checksum of different data must not match")
+	}
+}
+
+func TestWriteCSVFileScanError(t *testing.T) {
+	tempDir, _ := ioutil.TempDir("", utils.GetAppName()+"-test")
+	defer os.RemoveAll(tempDir)
+
+	db, _ := sql.Open("testdb", "")
+	defer db.Close()
+	defer testdb.Reset()
+
+	// the value of second row can not be scanned
+	sql := "select id, name from users"
+	columns := []string{"id", "name"}
+	testdb.StubQuery(sql, testdb.RowsFromSlice(columns, [][]driver.Value{{"1", "tim"}, {struct{}{}, "joe"}}))
+
+	res, _ := db.Query(sql)
+	csvResult, err := writeCSVFile(&writeCSVFileArgs{Rows: res, FileName: "users.csv", Path: tempDir})
+	if err == nil || csvResult != nil {
+		t.Errorf("[writeCSVFile] scan error not returned: %+v", csvResult)
+	}
+
+	res, _ = db.Query(sql)
+	countResult, err := countRows(res)
+	if err == nil || countResult != nil {
+		t.Errorf("[countRows] scan error not returned: %+v", countResult)
+	}
+
+	// the query is failed, not the rows and checksum of read rows
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	dbEngines["testdb"] = &dbEngine{
+		Driver:         "testdb",
+		DataSourceName: func(c *Command, endpoint *rds.Endpoint) string { return "" },
+	}
+	defer delete(dbEngines, "testdb")
+
+	result, err := tc.ExecuteSQL(&ExecuteSQLArgs{
+		Engine:   "testdb",
+		Endpoint: &rds.Endpoint{},
+		Queries:  []query.Query{{Name: "users", SQL: sql}},
+	})
+	if err == nil || len(result.Rows) != 0 {
+		t.Errorf("[ExecuteSQL] scan error not returned: %+v", result)
+	}
+}
+
+func TestResultChecksum(t *testing.T) {
+	cols := []string{"a", "b"}
+
+	// the length of value is hashed, so concatenated values are different
+	r1 := newResultChecksum(cols)
+	r1.addRow([][]byte{[]byte("ab"), []byte("c")})
+	r2 := newResultChecksum(cols)
+	r2.addRow([][]byte{[]byte("a"), []byte("bc")})
+	if r1.sum() == r2.sum() {
+		t.Error("checksum of different values must not match")
+	}
+
+	// NULL is different from empty string
+	r1 = newResultChecksum(cols)
+	r1.addRow([][]byte{nil, []byte("c")})
+	r2 = newResultChecksum(cols)
+	r2.addRow([][]byte{[]byte(""), []byte("c")})
+	if r1.sum() == r2.sum() {
+		t.Error("checksum of NULL and empty string must not match")
+	}
+
+	// same values are same checksum
+	r2 = newResultChecksum(cols)
+	r2.addRow([][]byte{nil, []byte("c")})
+	if r1.sum() != r2.sum() {
+		t.Error("checksum of same values must match")
+	}
+}
+
+func TestGetMaskQueries(t *testing.T) {
+	masks := []query.Mask{
+		{Table: "account", Column: "tel", Strategy: "null"},
+		{Table: "account", Column: "name", Strategy: "fixed", Value: `it's \masked`},
+		{Table: "test.account", Column: "password", Strategy: "hash"},
+		{Table: "account", Column: "email", Strategy: "EMAIL"},
+		{Table: "account", Column: "address", Strategy: "truncate", Length: 3},
+	}
+	sqls := []string{
+		"UPDATE `account` SET `tel` = NULL",
+		"UPDATE `account` SET `name` = 'it''s \\\\masked'",
+		"UPDATE `test`.`account` SET `password` = SHA2(`password`, 256) WHERE `password` IS NOT NULL",
+		"UPDATE `account` SET `email` = CONCAT('user-', LEFT(SHA2(`email`, 256), 16), '@example.com') WHERE `email` IS NOT NULL",
+		"UPDATE `account` SET `address` = LEFT(`address`, 3) WHERE `address` IS NOT NULL",
+	}
+
+	queries, err := getMaskQueries(masks)
+	if err != nil {
+		t.Fatalf("[getMaskQueries] result error: %s", err.Error())
+	}
+	for i, q := range queries {
+		if q.SQL != sqls[i] {
+			t.Errorf("mask sql not match: %s/%s", q.SQL, sqls[i])
+		}
+	}
+	if queries[3].Name != "account.email (email)" {
+		t.Errorf("mask name not match: %s", queries[3].Name)
+	}
+
+	if _, err := getMaskQueries([]query.Mask{{Table: "account", Strategy: "null"}}); err != ErrMaskTargetNotFound {
+		t.Errorf("[getMaskQueries] error not match: %v", err)
+	}
+	if _, err := getMaskQueries([]query.Mask{{Table: "account", Column: "tel", Strategy: "shuffle"}}); err != ErrMaskStrategyNotFound {
+		t.Errorf("[getMaskQueries] error not match: %v", err)
+	}
+}
+
+func TestGetMaskTextFailed(t *testing.T) {
+	masks, _ := getMaskQueries([]query.Mask{
+		{Table: "account", Column: "tel", Strategy: "null"},
+		{Table: "account", Column: "email", Strategy: "email"},
+	})
+	run := esRun{
+		DBIdentifier: "rds-try-test-db-1",
+		Masks:        masks,
+		MaskTimes:    []time.Duration{time.Second},
+		MaskErr:      errors.New("table 'account' is read only"),
+	}
+
+	text := run.getMaskText()
+	for _, s := range []string{"account.tel (null)", "mask error  : table 'account' is read only", "applied 1 of 2 masking rules, queries are not run"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("mask text not contains: %s", s)
+		}
+	}
+
+}
+
+func TestExecuteRunMaskFailed(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	// run the masking sql by testdb driver
+	dbEngines["testdb"] = &dbEngine{
+		Driver:         "testdb",
+		DataSourceName: func(c *Command, endpoint *rds.Endpoint) string { return "" },
+		Mask:           true,
+	}
+	defer delete(dbEngines, "testdb")
+	defer testdb.Reset()
+
+	masks, _ := getMaskQueries([]query.Mask{
+		{Table: "account", Column: "tel", Strategy: "null"},
+		{Table: "account", Column: "email", Strategy: "email"},
+	})
+	testdb.SetExecFunc(func(query string) (driver.Result, error) {
+		if query == masks[1].SQL {
+			return nil, errors.New("table 'account' is read only")
+		}
+		return testdb.NewResult(0, nil, 1, nil), nil
+	})
+	queried := 0
+	testdb.SetQueryFunc(func(query string) (driver.Rows, error) {
+		queried++
+		return testdb.RowsFromCSVString([]string{"tel"}, "090-0000-0000"), nil
+	})
+
+	engine := "testdb"
+	id := "rds-try-test-db-1"
+	version := "5.6.27"
+	restDB := &rds.DBInstance{
+		DBInstanceIdentifier: &id,
+		Engine:               &engine,
+		EngineVersion:        &version,
+		Endpoint:             &rds.Endpoint{},
+	}
+	queries := []query.Query{{Name: "select-account", SQL: "select tel from account"}}
+
+	ec := &EsCommand{Command: tc}
+	run, err := ec.executeRun(restDB, queries, nil, masks, nil)
+	if err != ErrMaskFailed {
+		t.Errorf("[executeRun] error not match: %v", err)
+	}
+	if run != nil {
+		t.Errorf("[executeRun] result must be nil: %+v", run)
+	}
+	// the queries are not run on the unmasked data
+	if queried != 0 {
+		t.Errorf("queries run after masking failed: %d", queried)
+	}
+}
+
+func TestFilterRetentionDBSnapshots(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	now := time.Now()
+	other := "rds-try-other-db"
+	available := "available"
+	var snapList []*rds.DBSnapshot
+	for i, days := range []int{10, 1, 5, 3, 20} {
+		id := fmt.Sprintf("snapshot-%d", i+1)
+		created := now.Add(-time.Duration(days) * 24 * time.Hour)
+		snapList = append(snapList, &rds.DBSnapshot{
+			DBSnapshotIdentifier: &id,
+			DBInstanceIdentifier: &tc.RDSConfig.DBId,
+			SnapshotCreateTime:   &created,
+			Status:               &available,
+		})
+	}
+	// the snapshot of other db and creating snapshot are never the target, and not counted
+	otherID := "snapshot-other"
+	creatingID := "snapshot-creating"
+	creating := "creating"
+	created := now.Add(-30 * 24 * time.Hour)
+	snapList = append(snapList,
+		&rds.DBSnapshot{DBSnapshotIdentifier: &otherID, DBInstanceIdentifier: &other, SnapshotCreateTime: &created, Status: &available},
+		&rds.DBSnapshot{DBSnapshotIdentifier: &creatingID, DBInstanceIdentifier: &tc.RDSConfig.DBId, SnapshotCreateTime: &now, Status: &creating})
+
+	getIDs := func(list []*rds.DBSnapshot) string {
+		var ids []string
+		for _, snap := range list {
+			ids = append(ids, *snap.DBSnapshotIdentifier)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	// not specified
+	if list := tc.filterRetentionDBSnapshots(snapList, now); len(list) != 0 {
+		t.Errorf("retention target must be empty: %s", getIDs(list))
+	}
+
+	// keep the latest 2 snapshots
+	tc.RDSConfig.RetentionCount = 2
+	if ids := getIDs(tc.filterRetentionDBSnapshots(snapList, now)); ids != "snapshot-3,snapshot-1,snapshot-5" {
+		t.Errorf("retention target not match: %s", ids)
+	}
+
+	// keep the latest 2 snapshots or younger than 7 days
+	tc.RDSConfig.RetentionDays = 7
+	if ids := getIDs(tc.filterRetentionDBSnapshots(snapList, now)); ids != "snapshot-1,snapshot-5" {
+		t.Errorf("retention target not match: %s", ids)
+	}
+
+	// keep younger than 4 days
+	tc.RDSConfig.RetentionCount = 0
+	tc.RDSConfig.RetentionDays = 4
+	if ids := getIDs(tc.filterRetentionDBSnapshots(snapList, now)); ids != "snapshot-3,snapshot-1,snapshot-5" {
+		t.Errorf("retention target not match: %s", ids)
+	}
+}
+
+func TestFilterRetentionDBClusterSnapshots(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBClusterSnapshotsResponse)
+	defer ts.Close()
+
+	snapList, _ := tc.describeDBClusterSnapshots(&rds.DescribeDBClusterSnapshotsInput{})
+	tc.RDSConfig.DBId = "rds-try-test-cluster-1"
+	tc.RDSConfig.RetentionCount = 1
+
+	// "creating" snapshot is not counted
+	list := tc.filterRetentionDBClusterSnapshots(snapList, time.Now())
+	if len(list) != 1 {
+		t.Fatalf("retention target count not match: %d", len(list))
+	}
+	if *list[0].DBClusterSnapshotIdentifier != "before-cluster-test-1" {
+		t.Errorf("retention target not match: %s", *list[0].DBClusterSnapshotIdentifier)
+	}
+}
+
+func TestExcludeRetentionSnapshots(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	created := "rds-try-test-snap-created"
+	restored := "rds-try-test-snap-restored"
+	over := "rds-try-test-snap-over"
+	cluster := "rds-try-test-cluster-snap-restored"
+
+	// the snapshot created by this run is kept
+	ec := &EsCommand{Command: tc}
+	ec.addCreatedResource(tc, &rds.DBSnapshot{DBSnapshotIdentifier: &created})
+	ec.addCreatedResource(tc, &rds.DBInstance{DBInstanceIdentifier: &over})
+	excludes := ec.getCreatedSnapshotIDs()
+	if len(excludes) != 1 || excludes[0] != created {
+		t.Fatalf("created snapshot ids not match: %v", excludes)
+	}
+
+	// the snapshot restored by other environment is kept
+	keeps := map[string]bool{created: true, restored: true, cluster: true}
+	snapList := excludeDBSnapshots([]*rds.DBSnapshot{
+		{DBSnapshotIdentifier: &created},
+		{DBSnapshotIdentifier: &restored},
+		{DBSnapshotIdentifier: &over},
+	}, keeps)
+	if len(snapList) != 1 || *snapList[0].DBSnapshotIdentifier != over {
+		t.Errorf("excluded snapshots not match: %v", snapList)
+	}
+
+	clusterSnapList := excludeDBClusterSnapshots([]*rds.DBClusterSnapshot{
+		{DBClusterSnapshotIdentifier: &cluster},
+	}, keeps)
+	if len(clusterSnapList) != 0 {
+		t.Errorf("excluded cluster snapshots not match: %v", clusterSnapList)
+	}
+
+	tag := getSnapshotTag(&restored)
+	if *tag.Key != rtSnapshotText || *tag.Value != restored {
+		t.Errorf("snapshot tag not match: %s=%s", *tag.Key, *tag.Value)
+	}
+}
+
+func TestRmRetentionNotFound(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	rc := &RmCommand{Command: tc, OptRetention: true}
+	if err := rc.runDetails(nil); err != ErrRetentionNotFound {
+		t.Errorf("[runDetails] error not match: %v", err)
+	}
+}
+
+func TestGetHistoryQueries(t *testing.T) {
+	q := []query.Query{
+		{
+			Name: "q1",
+			SQL:  "select * from account_id",
+		},
+	}
+	runs := []esRun{
+		{
+			DBIdentifier: "rds-try-test-db-1",
+			Queries:      q,
+			Times:        []time.Duration{time.Second},
+			Rows:         []int64{10},
+			Checksums:    []string{"abc"},
+		},
+		{
+			DBIdentifier: "rds-try-test-db-1-base",
+			Queries:      q,
+			Times:        []time.Duration{time.Second},
+		},
+	}
+
+	queries := getHistoryQueries(runs)
+	if len(queries) != 2 {
+		t.Fatalf("history queries count not match: %d", len(queries))
+	}
+	if queries[0].Rows != 10 || queries[0].Checksum != "abc" || queries[0].Name != "q1" {
+		t.Errorf("history query not match: %+v", queries[0])
+	}
+	if queries[1].DBIdentifier != "rds-try-test-db-1-base" || queries[1].Checksum != "" {
+		t.Errorf("history query not match: %+v", queries[1])
+	}
+}
+
+// It takes 30 seconds every time
+func TestWaitForStatusAvailable(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBInstanceResponse)
+	defer ts.Close()
+
+	t.Log("It takes 30 seconds every time")
+	fmt.Println(" ### It takes 30 seconds every time")
+
+	id := "rds-try-test-db-1"
+	ri, _ := tc.DescribeDBInstance(id)
+	state := tc.WaitForStatusAvailable(ri)
+
+	if !<-state {
+		t.Error("WaitForStatusAvailable not match")
+	}
+}
+
+func TestWaitForStatusAvailableInterrupt(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBInstanceResponse)
+	defer ts.Close()
+
+	interrupt := make(chan struct{})
+	tc.Interrupt = interrupt
+	close(interrupt)
+
+	id := "rds-try-test-db-1"
+	ri, _ := tc.DescribeDBInstance(id)
+	state := tc.WaitForStatusAvailable(ri)
+
+	select {
+	case ok := <-state:
+		if ok {
+			t.Error("WaitForStatusAvailable not match")
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("WaitForStatusAvailable not interrupted")
+	}
+}
+
+func TestGetContextInterrupt(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBInstanceResponse)
+	defer ts.Close()
+
+	interrupt := make(chan struct{})
+	tc.Interrupt = interrupt
+	ctx, cancel := tc.getContext()
+	defer cancel()
+
+	if ctx.Err() != nil {
+		t.Errorf("context is cancelled before interrupted: %s", ctx.Err().Error())
+	}
+
+	close(interrupt)
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Error("context not cancelled by interrupt")
+	}
+}
+
+func TestStartPhaseInterrupt(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBInstanceResponse)
+	defer ts.Close()
+
+	ec := &EsCommand{Command: tc}
+	ec.interrupt = make(chan struct{})
+	if err := ec.startPhase(nil, "restore"); err != nil {
+		t.Errorf("startPhase error: %s", err.Error())
+	}
+
+	// no more AWS call after interrupted
+	close(ec.interrupt)
+	if err := ec.startPhase(nil, "restore"); err != ErrInterruptedEs {
+		t.Errorf("startPhase not interrupted: %v", err)
+	}
+}
+
+func TestDescribeDBCluster(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBClustersResponse)
+	defer ts.Close()
+
+	id := "rds-try-test-cluster-1"
+	rc, err := tc.DescribeDBCluster(id)
+
+	if err != nil {
+		t.Errorf("[DescribeDBCluster] result error: %s", err.Error())
+	}
+	if *rc.DBClusterIdentifier != id {
+		t.Errorf("DBClusterIdentifier not match: %s/%s", *rc.DBClusterIdentifier, id)
+	}
+
+	ep := getDBClusterEndpoint(rc)
+	if *ep.Address != *rc.Endpoint || *ep.Port != 3306 {
+		t.Errorf("DB Cluster endpoint not match: %s:%d", *ep.Address, *ep.Port)
+	}
+}
+
+func TestIsDBClusterSource(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBInstanceResponse)
+	defer ts.Close()
+
+	cluster, err := tc.IsDBClusterSource("rds-try-test-db-1")
+	if err != nil {
+		t.Errorf("[IsDBClusterSource] result error: %s", err.Error())
+	}
+	if cluster {
+		t.Error("DB Instance source must not be cluster")
+	}
+
+	// DB Instance is not found, and DB Cluster is found
+	tsc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("Action") == "DescribeDBInstances" {
+			w.WriteHeader(404)
+			fmt.Fprintln(w, `<ErrorResponse><Error><Type>Sender</Type><Code>DBInstanceNotFound</Code><Message>DBInstance not found</Message></Error></ErrorResponse>`)
+			return
+		}
+		fmt.Fprintln(w, srDescribeDBClustersResponse)
+	}))
+	defer tsc.Close()
+	awsConf := aws.NewConfig()
+	awsConf = awsConf.WithCredentials(credentials.NewStaticCredentials("awsAccesskey1", "awsSecretKey2", ""))
+	awsConf = awsConf.WithRegion(tc.RDSConfig.Region)
+	awsConf = awsConf.WithEndpoint(tsc.URL)
+	awsConf = awsConf.WithMaxRetries(0)
+	tc.RDSClient = rds.New(session.New(awsConf))
+
+	cluster, err = tc.IsDBClusterSource("rds-try-test-cluster-1")
+	if err != nil {
+		t.Errorf("[IsDBClusterSource] result error: %s", err.Error())
+	}
+	if !cluster {
+		t.Error("DB Cluster source must be cluster")
+	}
+}
+
+func TestDescribeLatestDBClusterSnapshot(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBClusterSnapshotsResponse)
+	defer ts.Close()
+
+	id := "rds-try-test-cluster-1"
+	rs, err := tc.DescribeLatestDBClusterSnapshot(id)
+
+	if err != nil {
+		t.Errorf("[DescribeLatestDBClusterSnapshot] result error: %s", err.Error())
+	}
+	// "creating" snapshot is not the target
+	sid := "rds:rds-try-test-cluster-1-2016-05-12-03-05"
+	if *rs.DBClusterSnapshotIdentifier != sid {
+		t.Errorf("DBClusterSnapshotIdentifier not match: %s/%s", *rs.DBClusterSnapshotIdentifier, sid)
+	}
+}
+
+func TestRestoreDBClusterFromSnapshot(t *testing.T) {
+	tsc, tcc := getTestClient(200, srDescribeDBClustersResponse)
+	defer tsc.Close()
+
+	id := "rds-try-test-cluster-1"
+	rc, _ := tcc.DescribeDBCluster(id)
+
+	tss, tcs := getTestClient(200, srDescribeDBClusterSnapshotsResponse)
+	defer tss.Close()
+
+	rs, _ := tcs.DescribeDBClusterSnapshot("before-cluster-test-1")
+
+	tsr, tcr := getTestClient(200, srRestoreDBClusterFromSnapshotResponse)
+	defer tsr.Close()
+
+	args := &RestoreDBClusterFromSnapshotArgs{
+		DBIdentifier: id,
+		Snapshot:     rs,
+		Cluster:      rc,
+	}
+
+	rr, err := tcr.RestoreDBClusterFromSnapshot(args)
+
+	if err != nil {
+		t.Errorf("[RestoreDBClusterFromSnapshot] result error: %s", err.Error())
+	}
+	if *rr.DBClusterIdentifier != id {
+		t.Errorf("DBClusterIdentifier not match: %s/%s", *rr.DBClusterIdentifier, id)
+	}
+	if *rr.Status != "creating" {
+		t.Errorf("Status not match: %s/%s", *rr.Status, "creating")
+	}
+}
+
+func TestCreateDBClusterInstance(t *testing.T) {
+	ts, tc := getTestClient(200, srCreateDBInstanceResponse)
+	defer ts.Close()
+
+	cid := "rds-try-test-cluster-1"
+	id := cid + "-writer"
+	eg := "aurora"
+	args := &CreateDBClusterInstanceArgs{
+		DBIdentifier:    id,
+		DBInstanceClass: "db.r3.large",
+		Cluster:         &rds.DBCluster{DBClusterIdentifier: &cid, Engine: &eg},
+	}
+
+	ri, err := tc.CreateDBClusterInstance(args)
+
+	if err != nil {
+		t.Errorf("[CreateDBClusterInstance] result error: %s", err.Error())
+	}
+	if *ri.DBInstanceIdentifier != id {
+		t.Errorf("DBInstanceIdentifier not match: %s/%s", *ri.DBInstanceIdentifier, id)
+	}
+	if *ri.DBClusterIdentifier != cid {
+		t.Errorf("DBClusterIdentifier not match: %s/%s", *ri.DBClusterIdentifier, cid)
+	}
+}
+
+func TestDeleteDBCluster(t *testing.T) {
+	ts, tc := getTestClient(200, srDeleteDBClusterResponse)
+	defer ts.Close()
+
+	id := "rds-try-test-cluster-1"
+	rc, err := tc.DeleteDBCluster(id)
+
+	if err != nil {
+		t.Errorf("[DeleteDBCluster] result error: %s", err.Error())
+	}
+	if *rc.DBClusterIdentifier != id {
+		t.Errorf("DBClusterIdentifier not match: %s/%s", *rc.DBClusterIdentifier, id)
+	}
+	if *rc.Status != "deleting" {
+		t.Errorf("Status not match: %s/%s", *rc.Status, "deleting")
+	}
+
+	text := getCreatedResourceText(createdResource{command: tc, resource: rc})
+	if text != "DB Cluster: "+id+" in "+tc.RDSConfig.Region {
+		t.Errorf("created resource text not match: %s", text)
+	}
+}
+
+func TestCleanupCreatedResources(t *testing.T) {
+	ts, tc := getTestClient(200, srDeleteDBInstanceResponse)
+	defer ts.Close()
+
+	id := "rds-try-test-db-1"
+	ec := &EsCommand{Command: tc}
+	ec.RDSConfig.OnInterrupt = interruptDelete
+	ec.addCreatedResource(tc, &rds.DBInstance{DBInstanceIdentifier: &id})
+
+	text := getCreatedResourceText(ec.created[0])
+	if text != "DB Instance: "+id+" in "+tc.RDSConfig.Region {
+		t.Errorf("created resource text not match: %s", text)
+	}
+
+	// deleted without question
+	if remains := ec.cleanupCreatedResources(); len(remains) != 0 {
+		t.Errorf("remaining resources count not match: %d", len(remains))
+	}
+
+	// kept without question
+	ec.RDSConfig.OnInterrupt = interruptKeep
+	if remains := ec.cleanupCreatedResources(); len(remains) != 1 {
+		t.Errorf("remaining resources count not match: %d", len(remains))
+	}
+}
+
+func TestSendNotify(t *testing.T) {
+	var events []notify.Event
+	ns := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event := notify.Event{}
+		json.NewDecoder(r.Body).Decode(&event)
+		events = append(events, event)
+	}))
+	defer ns.Close()
+
+	ts, tc := getTestClient(200, srDeleteDBInstanceResponse)
+	defer ts.Close()
+	tc.Name = "default"
+	tc.Notifier = notify.NewNotifier(config.NotifyConfig{URLs: []string{ns.URL}})
+
+	id := "rds-try-test-db-1"
+	ec := &EsCommand{Command: tc}
+	ec.addCreatedResource(tc, &rds.DBInstance{DBInstanceIdentifier: &id})
+	ec.sendNotify(nil, ec.created)
+	ec.sendNotify(ErrDBInstancetTimeOut, nil)
+
+	rc := &RmCommand{Command: tc}
+	rc.sendNotify(getDeletedResourceTexts(tc, []*rds.DBInstance{{DBInstanceIdentifier: &id}}))
+	// nothing is notified if not deleted
+	rc.sendNotify(nil)
+
+	if len(events) != 3 {
+		t.Fatalf("notified count not match: %d", len(events))
+	}
+	if events[0].Command != "es" || events[0].Status != notify.StatusSucceeded || len(events[0].Resources) != 1 {
+		t.Errorf("es succeeded event not match: %+v", events[0])
+	}
+	if events[1].Status != notify.StatusTimeout || events[1].Message != ErrDBInstancetTimeOut.Error() {
+		t.Errorf("es timeout event not match: %+v", events[1])
+	}
+	if events[2].Command != "rm" || events[2].Status != notify.StatusDeleted || events[2].Resources[0] != "DB Instance: "+id+" in "+tc.RDSConfig.Region {
+		t.Errorf("rm deleted event not match: %+v", events[2])
+	}
+}
+
+func TestParseSchedules(t *testing.T) {
+	if _, err := parseSchedules(nil); err != ErrScheduleNotFound {
+		t.Errorf("schedule error not match: %v", err)
+	}
+	if _, err := parseSchedules([]config.ScheduleConfig{{Cron: "0 25 * * *"}}); err != cron.ErrInvalidSpec {
+		t.Errorf("cron error not match: %v", err)
+	}
+
+	entries, err := parseSchedules([]config.ScheduleConfig{
+		{
+			Cron:    "0 2 * * *",
+			Query:   "/home/awsuser/nightly.query",
+			Options: []string{"--ttl", "6h"},
+		},
+	})
+	if err != nil {
+		t.Errorf("[parseSchedules] result error: %s", err.Error())
+	}
+	if entries[0].Name != defaultScheduleName {
+		t.Errorf("schedule name not match: %s", entries[0].Name)
+	}
+	if args := strings.Join(entries[0].getArgs(), " "); args != "-q /home/awsuser/nightly.query --ttl 6h" {
+		t.Errorf("schedule args not match: %s", args)
+	}
+
+	sc := &ScheduleCommand{entries: entries}
+	text := sc.getListText(time.Date(2015, 2, 25, 12, 0, 0, 0, time.Local))
+	for _, s := range []string{"default: 0 2 * * * (next: 2015-02-26 02:00:00)", "es -q /home/awsuser/nightly.query --ttl 6h"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("schedule list text not contains: %s", s)
+		}
+	}
+}
+
+func TestScheduleStartMatched(t *testing.T) {
+	tempDir, _ := ioutil.TempDir("", utils.GetAppName()+"-test")
+	defer os.RemoveAll(tempDir)
+
+	entries, _ := parseSchedules([]config.ScheduleConfig{
+		{Cron: "* * * * *", Name: "test1"},
+		{Cron: "* * * * *", Name: "test2", Options: []string{"--fail"}},
+		{Cron: "0 0 1 1 *", Name: "test3"},
+	})
+
+	// es of test1 is blocked until released
+	release := make(chan struct{})
+	var argsMutex sync.Mutex
+	var runArgs []string
+	sc := &ScheduleCommand{
+		Command:    &Command{HistoryFile: history.GetPath(tempDir)},
+		ConfigFile: "/home/awsuser/rds-try.conf",
+		entries:    entries,
+		runner: func(args []string) ([]byte, error) {
+			argsMutex.Lock()
+			runArgs = append(runArgs, strings.Join(args, " "))
+			argsMutex.Unlock()
+
+			if args[len(args)-1] == "--fail" {
+				return []byte("start command : es\r\nDB Instance Class is not found\r\n"), errors.New("exit status 1")
+			}
+			<-release
+			return nil, nil
+		},
+	}
+
+	now := time.Date(2015, 2, 25, 12, 0, 0, 0, time.Local)
+	sc.startMatched(now)
+	// test2 is ended, and test1 is still running
+	for !sc.startRunning("test2") {
+		time.Sleep(time.Millisecond)
+	}
+	sc.endRunning("test2")
+	sc.startMatched(now.Add(time.Minute))
+	close(release)
+	sc.wait.Wait()
+
+	if len(runArgs) != 3 || !strings.Contains(strings.Join(runArgs, ","), "-c /home/awsuser/rds-try.conf -n test1 es") {
+		t.Errorf("run args not match: %v", runArgs)
+	}
+
+	entriesText := ""
+	loaded, _ := history.Load(sc.HistoryFile)
+	for _, entry := range loaded {
+		entriesText += entry.Name + ":" + entry.Status + ","
+	}
+	for _, s := range []string{"test1:started", "test1:skipped", "test1:succeeded", "test2:failed"} {
+		if !strings.Contains(entriesText, s) {
+			t.Errorf("history not contains: %s", s)
+		}
+	}
+	// the tail of es output is the message of failure
+	for _, entry := range loaded {
+		if entry.Status == history.StatusFailed && entry.Message != "exit status 1: start command : es\nDB Instance Class is not found" {
+			t.Errorf("history message not match: %s", entry.Message)
+		}
+	}
+	if strings.Contains(entriesText, "test3") {
+		t.Errorf("not matched schedule is run: %s", entriesText)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	// the phases are shown on one line
+	out := &bytes.Buffer{}
+	p := &progress{
+		out: out,
+		tty: true,
+	}
+	p.setPhase("restore")
+	p.setStatus("creating")
+	p.setPhase("query 1 of 2")
+	p.stop()
+	p.stop()
+
+	phases := p.getPhases()
+	if len(phases) != 2 || phases[0].Name != "restore" || phases[1].Name != "query 1 of 2" {
+		t.Errorf("progress phases not match: %+v", phases)
+	}
+
+	text := out.String()
+	for _, s := range []string{"restore, status: creating", "restore done", "query 1 of 2 done"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("progress text not contains: %s", s)
+		}
+	}
+
+	// the phases are shown by log lines if not terminal
+	tempFile, _ := ioutil.TempFile("", utils.GetAppName()+"-test")
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	fp := newProgress(tempFile)
+	if fp.tty {
+		t.Error("progress of file must not be terminal")
+	}
+	fp.setPhase("restore")
+	fp.stop()
+
+	// nothing is done with nil
+	var np *progress
+	np.setPhase("restore")
+	np.setStatus("creating")
+	np.stop()
+}
+
+func TestGetElapsedText(t *testing.T) {
+	text := getElapsedText(time.Hour + 2*time.Minute + 3*time.Second)
+	if text != "01:02:03" {
+		t.Errorf("elapsed text not match: %s", text)
+	}
+}
+
+func TestGetStorageSettings(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	st := "gp2"
+	var size int64 = 5
+	di := &rds.DBInstance{
+		StorageType: &st,
+	}
+	ds := &rds.DBSnapshot{
+		AllocatedStorage: &size,
+	}
+
+	// running DB Instance and DB Snapshot value
+	ec := &EsCommand{Command: tc}
+	ss := ec.getStorageSettings(di, ds)
+	if ss.StorageType != st || ss.Iops != 0 || ss.AllocatedStorage != size {
+		t.Errorf("storage settings not match: %+v", ss)
+	}
+
+	// read replica has no DB Snapshot
+	var dbSize int64 = 10
+	di.AllocatedStorage = &dbSize
+	ss = ec.getStorageSettings(di, nil)
+	if ss.AllocatedStorage != dbSize {
+		t.Errorf("storage settings not match: %+v", ss)
+	}
+
+	// config file value
+	ec.RDSConfig.StorageType = "io1"
+	ec.RDSConfig.Iops = 1000
+	ec.RDSConfig.AllocatedStorage = 100
+	ss = ec.getStorageSettings(di, ds)
+	if ss.StorageType != "io1" || ss.Iops != 1000 || ss.AllocatedStorage != 100 {
+		t.Errorf("storage settings not match: %+v", ss)
+	}
+
+	// argument value, iops is available only "io1"
+	ec.OptStorageType = "standard"
+	ec.OptAllocatedStorage = 200
+	ss = ec.getStorageSettings(di, ds)
+	if ss.StorageType != "standard" || ss.Iops != 0 || ss.AllocatedStorage != 200 {
+		t.Errorf("storage settings not match: %+v", ss)
+	}
+}
+
+func TestCheckStorageSettings(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	st := "io1"
+	var iops int64 = 1000
+	di := &rds.DBInstance{
+		StorageType: &st,
+		Iops:        &iops,
+	}
+
+	// iops of running DB Instance is used
+	ec := &EsCommand{Command: tc, OptStorageType: "io1"}
+	if err := ec.checkStorageSettings(di); err != nil {
+		t.Errorf("storage settings error not match: %v", err)
+	}
+
+	// io1 without iops is checked after the running DB Instance is described
+	if err := ec.checkStorageSettings(nil); err != nil {
+		t.Errorf("storage settings error not match: %v", err)
+	}
+	if err := ec.checkStorageSettings(&rds.DBInstance{}); err != ErrIopsNotFound {
+		t.Errorf("storage settings error not match: %v", err)
+	}
+
+	// specified iops is not dropped with other storage type
+	ec.OptStorageType = "gp2"
+	if err := ec.checkStorageSettings(di); err != nil {
+		t.Errorf("storage settings error not match: %v", err)
+	}
+	ec.OptIops = 2000
+	if err := ec.checkStorageSettings(nil); err != ErrIopsNotSupported {
+		t.Errorf("storage settings error not match: %v", err)
+	}
+}
+
+func TestEsSummaryGetText(t *testing.T) {
+	q := []query.Query{
+		{
+			Name: "q1",
+			SQL:  "select * from account_id",
+		},
+	}
+	times := []time.Duration{time.Second}
+	summary := &esSummary{
+		DBInstanceClass: "db.m3.medium",
+		Storage: storageSettings{
+			StorageType:      "gp2",
+			AllocatedStorage: 5,
+		},
+		Encrypted: true,
+		KmsKeyID:  "arn:aws:kms:us-west-2:123456789012:key/test-key",
+		Cost: &costResult{
+			Lifetime: time.Hour,
+			Cost:     0.125,
+		},
+		Upgrade: &upgradeResult{
+			FromVersion: "5.6.23",
+			ToVersion:   "5.7.10",
+			Time:        time.Minute,
+		},
+		Runs: []esRun{
+			{
+				DBIdentifier:  "rds-try-test-db-1",
+				EngineVersion: "5.7.10",
+				Queries:       q,
+				Times:         times,
+				Rows:          []int64{3},
+				Checksums:     []string{"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+			},
+			{
+				DBIdentifier:  "rds-try-test-db-1-base",
+				EngineVersion: "5.6.23",
+				Queries:       q,
+				Times:         times,
+			},
+		},
+	}
+
+	text := summary.getText()
+	for _, s := range []string{"result rows  : 3", "result sha256: e3b0c44298fc", "db.m3.medium", "encrypted        : true", "key/test-key", "estimated cost   : 0.1250", "5.6.23 -> 5.7.10", "rds-try-test-db-1 (5.7.10)", "rds-try-test-db-1-base (5.6.23)"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("summary text not contains: %s", s)
+		}
+	}
+
+	// source of read replica is shown instead of db snapshot
+	summary.SourceID = "rds-try-test-db-1"
+	summary.Replica = true
+	text = summary.getText()
+	if !strings.Contains(text, "read replica of  : rds-try-test-db-1") || strings.Contains(text, "db snapshot") {
+		t.Error("summary text not contains read replica")
+	}
+	summary.Replica = false
+
+	// storage is not shown for db cluster
+	summary.DBClusterID = "rds-try-test-cluster-1"
+	text = summary.getText()
+	if !strings.Contains(text, "db cluster       : rds-try-test-cluster-1") {
+		t.Error("summary text not contains db cluster")
+	}
+	if strings.Contains(text, "gp2") {
+		t.Error("summary text must not contain storage type of db cluster")
+	}
+}
+
+func TestEsSummaryGetReport(t *testing.T) {
+	q := []query.Query{
+		{
+			Name: "q1",
+			SQL:  "select * from account_id",
+		},
+		{
+			Name: "<q2>",
+			SQL:  "select count(*) from account_id",
+		},
+	}
+	now := time.Date(2015, 2, 25, 12, 0, 0, 0, time.UTC)
+	snapTime := now.Add(-3 * time.Hour)
+	summary := &esSummary{
+		EnvName:         "default",
+		Region:          "us-west-2",
+		SourceID:        "rds-try-test-db",
+		SnapshotID:      "rds-try-test-snap",
+		SnapshotTime:    &snapTime,
+		DBInstanceClass: "db.m3.medium",
+		Phases: []phaseResult{
+			{
+				Name: "restore",
+				Time: 10 * time.Minute,
+			},
+		},
+		Runs: []esRun{
+			{
+				DBIdentifier:  "rds-try-test-db-1",
+				EngineVersion: "5.6.23",
+				Queries:       q,
+				Times:         []time.Duration{2 * time.Second, time.Second},
+				Rows:          []int64{3, 1},
+				Checksums:     []string{"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", ""},
+				Files:         []string{"/tmp/q1-2015-02-25-12-00-00.csv", ""},
+			},
+		},
+	}
+
+	text, err := summary.getReport(reportMarkdown, now)
+	if err != nil {
+		t.Errorf("[getReport] result error: %s", err.Error())
+	}
+	for _, s := range []string{"| environment | default |", "| snapshot age | 3h0m0s |", "| restore | 00:10:00 |", "| q1 | 2s | 3 | e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855 | [q1-2015-02-25-12-00-00.csv](q1-2015-02-25-12-00-00.csv) |", "| <q2> | 1s | 1 | - | - |"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("markdown report not contains: %s", s)
+		}
+	}
+
+	text, err = summary.getReport(reportHTML, now)
+	if err != nil {
+		t.Errorf("[getReport] result error: %s", err.Error())
+	}
+	for _, s := range []string{"<td>rds-try-test-db</td>", "&lt;q2&gt;", `<a href="q1-2015-02-25-12-00-00.csv">`, "width: 100.0%", "width: 50.0%"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("html report not contains: %s", s)
+		}
+	}
+
+	_, err = summary.getReport("pdf", now)
+	if err != ErrReportFormatNotFound {
+		t.Errorf("report format error not match: %v", err)
+	}
+}
+
+func TestEsSummaryGetReportEscape(t *testing.T) {
+	now := time.Date(2015, 2, 25, 12, 0, 0, 0, time.UTC)
+	summary := &esSummary{
+		EnvName: "dev|test",
+		Phases: []phaseResult{
+			{
+				Name: "run\nqueries",
+				Time: time.Second,
+			},
+		},
+		Runs: []esRun{
+			{
+				DBIdentifier:  "rds-try-test-db-1",
+				EngineVersion: "5.6.23",
+				Queries: []query.Query{
+					{
+						Name: "a|b\r\nc",
+						SQL:  "select * from account_id",
+					},
+				},
+				Times: []time.Duration{time.Second},
+				Rows:  []int64{1},
+			},
+		},
+	}
+
+	text, err := summary.getReport(reportMarkdown, now)
+	if err != nil {
+		t.Errorf("[getReport] result error: %s", err.Error())
+	}
+	for _, s := range []string{"| environment | dev\\|test |", "| run<br>queries | 00:00:01 |", "| a\\|b<br>c | 1s | 1 | - | - |"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("markdown report not contains: %s", s)
+		}
+	}
+}
+
+func TestGetMigrationText(t *testing.T) {
+	m := []query.Query{
+		{
+			Name: "m1",
+			SQL:  "ALTER TABLE account ADD COLUMN age INT",
+		},
+		{
+			Name: "m2",
+			SQL:  "CREATE INDEX idx_age ON account (age)",
+		},
+		{
+			Name: "m3",
+			SQL:  "UPDATE account SET age = 0",
+		},
+	}
+	run := &esRun{
+		DBIdentifier:   "rds-try-test-db-1",
+		Migrations:     m,
+		MigrationTimes: []time.Duration{time.Second},
+	}
+
+	err := &MigrationError{
+		Index: 1,
+		Name:  "m2",
+		Err:   errors.New("duplicate key name"),
+	}
+	text := run.getMigrationText(err)
+	for _, s := range []string{"m1", "duplicate key name", "not applied      : m3", "applied 1 of 3 statements"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("migration text not contains: %s", s)
+		}
+	}
+}
+
+func TestGetModifiedSettings(t *testing.T) {
+	ts, tc := getTestClient(200, srDescribeDBInstanceResponse)
+	defer ts.Close()
+
+	id := "rds-try-test-db-1"
+	src, _ := tc.DescribeDBInstance(id)
+	rest, _ := tc.DescribeDBInstance(id)
+
+	// same settings
+	settings := tc.GetModifiedSettings(rest, src, 0)
+	if len(settings) != 0 {
+		t.Errorf("modified settings count not match: %v", settings)
+	}
+
+	// default settings of restored db instance
+	group := "default.mysql5.6"
+	sg := "sg-default"
+	rest.DBParameterGroups = []*rds.DBParameterGroupStatus{
+		{DBParameterGroupName: &group},
+	}
+	rest.VpcSecurityGroups = []*rds.VpcSecurityGroupMembership{
+		{VpcSecurityGroupId: &sg},
+	}
+	settings = tc.GetModifiedSettings(rest, src, *src.AllocatedStorage+10)
+	if len(settings) != 3 {
+		t.Errorf("modified settings count not match: %v", settings)
+	}
+
+	// iam database authentication is enabled by "iam_auth"
+	tc.RDSConfig.IAMAuth = true
+	settings = tc.GetModifiedSettings(src, src, 0)
+	if len(settings) != 1 || settings[0] != modifiedIAMAuthText {
+		t.Errorf("modified settings not match: %v", settings)
+	}
+
+	enabled := true
+	iamDB := *src
+	iamDB.IAMDatabaseAuthenticationEnabled = &enabled
+	settings = tc.GetModifiedSettings(&iamDB, src, 0)
+	if len(settings) != 0 {
+		t.Errorf("modified settings not match: %v", settings)
+	}
+}
+
+func TestGetAuthToken(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	addr := "rds-try-test-db.c6c2mntzugv0.us-west-2.rds.amazonaws.com"
+	var port int64 = 3306
+	ep := &rds.Endpoint{Address: &addr, Port: &port}
+
+	token, err := tc.getAuthToken(ep)
+	if err != nil {
+		t.Fatalf("auth token error: %s", err.Error())
+	}
+	if !strings.HasPrefix(token, addr+":3306?Action=connect&DBUser="+tc.RDSConfig.User) {
+		t.Errorf("auth token not match: %s", token)
+	}
+	if !strings.Contains(token, "X-Amz-Signature=") {
+		t.Errorf("auth token is not signed: %s", token)
+	}
+
+	// the auth token is sent as clear text over tls
+	tc.RDSConfig.IAMAuth = true
+	if !strings.HasSuffix(tc.getMySQLDataSourceName(ep), "/?tls=rds-try-"+tc.Name+"&allowCleartextPasswords=true") {
+		t.Errorf("DB data source name not match: %s", tc.getMySQLDataSourceName(ep))
+	}
+	tlsConfig, err := tc.getTLSConfig(ep)
+	if err != nil || !tlsConfig.InsecureSkipVerify {
+		t.Errorf("tls config not match: %+v", tlsConfig)
+	}
+}
+
+func TestOpenAuthDB(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	// the password is the data source name of testdb driver
+	dbEngines["testdb"] = &dbEngine{
+		Driver:         "testdb",
+		DataSourceName: func(c *Command, endpoint *rds.Endpoint) string { return c.RDSConfig.Pass },
+	}
+	defer delete(dbEngines, "testdb")
+	defer testdb.Reset()
+
+	var dsns []string
+	testdb.SetOpenFunc(func(dsn string) (driver.Conn, error) {
+		dsns = append(dsns, dsn)
+		return testdb.Conn(), nil
+	})
+
+	addr := "rds-try-test-db.c6c2mntzugv0.us-west-2.rds.amazonaws.com"
+	var port int64 = 3306
+	tc.RDSConfig.IAMAuth = true
+	db, err := tc.openDB(&ExecuteSQLArgs{Engine: "testdb", Endpoint: &rds.Endpoint{Address: &addr, Port: &port}})
+	if err != nil {
+		t.Fatalf("open db error: %s", err.Error())
+	}
+	defer db.Close()
+
+	// the auth token is generated for each connection
+	db.SetMaxIdleConns(0)
+	db.Ping()
+	db.Ping()
+	if len(dsns) != 2 {
+		t.Fatalf("connection count not match: %d", len(dsns))
+	}
+	for _, dsn := range dsns {
+		if !strings.HasPrefix(dsn, addr+":3306?Action=connect&DBUser="+tc.RDSConfig.User) {
+			t.Errorf("auth token not match: %s", dsn)
+		}
+	}
+}
+
+func TestCheckIAMAuth(t *testing.T) {
+	ts, tc := getTestClient(200, "")
+	defer ts.Close()
+
+	tc.RDSConfig.IAMAuth = true
+	if err := tc.CheckIAMAuth(); err != nil {
+		t.Errorf("iam auth error not match: %v", err)
+	}
+
+	tc.RDSConfig.SSLMode = "disable"
+	if err := tc.CheckIAMAuth(); err != ErrIAMAuthWithoutTLS {
+		t.Errorf("iam auth error not match: %v", err)
+	}
+
+	// oracle and sql server do not support iam database authentication
+	tc.RDSConfig.SSLMode = ""
+	eg := "aurora-postgresql"
+	if err := tc.checkDBEngine(&eg, false); err != nil {
+		t.Errorf("DB engine error not match: %v", err)
+	}
+	eg = "oracle-ee"
+	if err := tc.checkDBEngine(&eg, false); err != ErrIAMAuthNotSupported {
+		t.Errorf("DB engine error not match: %v", err)
+	}
+}
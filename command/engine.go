@@ -0,0 +1,133 @@
+package command
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+var (
+	// ErrEngineNotSupported is the "DB engine is not supported" error
+	ErrEngineNotSupported = errors.New("DB engine is not supported")
+	// ErrMaskNotSupported is the "masking rules are not supported by DB engine" error
+	ErrMaskNotSupported = errors.New("masking rules are not supported by DB engine")
+)
+
+// dbEngine struct is the Driver and DataSourceName and RegisterTLS and Batches and SingleSession and Mask and IAMAuth and SSLModes and SSLServerName variable
+type dbEngine struct {
+	Driver         string                                          // golang db driver name
+	DataSourceName func(c *Command, endpoint *rds.Endpoint) string // build data source name from config and endpoint
+	RegisterTLS    func(c *Command, endpoint *rds.Endpoint) error  // register tls config to driver, nil if not needed
+	Batches        func(sql string) []string                       // nil if sql is run as it is
+	SingleSession  bool                                            // the batches share the session
+	Mask           bool                                            // the masking sql is able to be run
+	IAMAuth        bool                                            // iam database authentication is supported
+	SSLModes       []string                                        // supported "ssl_mode", "ssl_ca" is used by the verify modes
+	SSLServerName  bool                                            // "ssl_server_name" is supported
+}
+
+var (
+	mysqlEngine = &dbEngine{
+		Driver:         "mysql",
+		DataSourceName: (*Command).getMySQLDataSourceName,
+		RegisterTLS:    (*Command).registerMySQLTLSConfig,
+		Mask:           true,
+		IAMAuth:        true,
+		SSLModes:       []string{sslModeDisable, sslModeRequire, sslModeVerifyCA, sslModeVerifyFull},
+		SSLServerName:  true,
+	}
+	// the server name is always the host of libpq
+	postgresEngine = &dbEngine{
+		Driver:         "postgres",
+		DataSourceName: (*Command).getPostgresDataSourceName,
+		IAMAuth:        true,
+		SSLModes:       []string{sslModeDisable, sslModeRequire, sslModeVerifyCA, sslModeVerifyFull},
+	}
+	// the certificate is verified only by the oracle wallet
+	oracleEngine = &dbEngine{
+		Driver:         "oracle",
+		DataSourceName: (*Command).getOracleDataSourceName,
+		Batches:        getOracleBatches,
+		SSLModes:       []string{sslModeDisable, sslModeRequire},
+	}
+	// the server name is always verified with the certificate
+	sqlserverEngine = &dbEngine{
+		Driver:         "sqlserver",
+		DataSourceName: (*Command).getSQLServerDataSourceName,
+		Batches:        getSQLServerBatches,
+		SingleSession:  true,
+		SSLModes:       []string{sslModeDisable, sslModeRequire, sslModeVerifyFull},
+		SSLServerName:  true,
+	}
+)
+
+// convert from "aws engine name" to "golang db driver"
+// see also
+// CreateDBInstance - Amazon Relational Database Service
+// http://docs.aws.amazon.com/AmazonRDS/latest/APIReference/API_CreateDBInstance.html
+// Request Parameters "Engine" is Valid Values
+//
+// SQLDrivers · golang/go Wiki · GitHub
+// https://github.com/golang/go/wiki/SQLDrivers
+//
+// "aurora" is Aurora MySQL 5.6 compatible, and mariadb is opened as mysql
+var dbEngines = map[string]*dbEngine{
+	"mysql":             mysqlEngine,
+	"mariadb":           mysqlEngine,
+	"aurora":            mysqlEngine,
+	"aurora-mysql":      mysqlEngine,
+	"postgres":          postgresEngine,
+	"aurora-postgresql": postgresEngine,
+	"oracle-ee":         oracleEngine,
+	"oracle-ee-cdb":     oracleEngine,
+	"oracle-se2":        oracleEngine,
+	"oracle-se2-cdb":    oracleEngine,
+	"oracle-se1":        oracleEngine,
+	"oracle-se":         oracleEngine,
+	"sqlserver-ee":      sqlserverEngine,
+	"sqlserver-se":      sqlserverEngine,
+	"sqlserver-ex":      sqlserverEngine,
+	"sqlserver-web":     sqlserverEngine,
+}
+
+// the engine name is case insensitive, ex. "MySQL"
+func getDBEngine(engine string) (*dbEngine, error) {
+	dbEngine, ok := dbEngines[strings.ToLower(engine)]
+	if !ok {
+		log.Errorf("%s: %s", ErrEngineNotSupported.Error(), engine)
+		return nil, ErrEngineNotSupported
+	}
+
+	return dbEngine, nil
+}
+
+// checked before restore not to leave the db instance that can not run sql
+// masked is true if there are masking rules
+func (c *Command) checkDBEngine(engine *string, masked bool) error {
+	if engine == nil {
+		log.Errorf("%s", ErrEngineNotSupported.Error())
+		return ErrEngineNotSupported
+	}
+
+	dbEngine, err := getDBEngine(*engine)
+	if err != nil {
+		return err
+	}
+
+	// the masking sql is written for mysql
+	if masked && !dbEngine.Mask {
+		log.Errorf("%s: %s", ErrMaskNotSupported.Error(), *engine)
+		return ErrMaskNotSupported
+	}
+	if c.RDSConfig.IAMAuth && !dbEngine.IAMAuth {
+		log.Errorf("%s: %s", ErrIAMAuthNotSupported.Error(), *engine)
+		return ErrIAMAuthNotSupported
+	}
+	if err := c.checkSSLSettings(dbEngine); err != nil {
+		log.Errorf("%s: %s", err.Error(), *engine)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/rds/rdsutils"
+)
+
+var (
+	// ErrIAMAuthNotSupported is the "iam database authentication is not supported by DB engine" error
+	ErrIAMAuthNotSupported = errors.New("iam database authentication is not supported by DB engine")
+	// ErrIAMAuthWithoutTLS is the "iam database authentication needs ssl_mode except disable" error
+	ErrIAMAuthWithoutTLS = errors.New("iam database authentication needs ssl_mode except disable")
+)
+
+// CheckIAMAuth is check "iam_auth" is able to be used with "ssl_mode"
+// the auth token is sent over tls
+func (c *Command) CheckIAMAuth() error {
+	if c.RDSConfig.IAMAuth && c.RDSConfig.SSLMode == sslModeDisable {
+		log.Errorf("%s", ErrIAMAuthWithoutTLS.Error())
+		return ErrIAMAuthWithoutTLS
+	}
+
+	return nil
+}
+
+// return the auth token of "user" for the endpoint, it is used as the password
+// the token is signed by the credentials and region of rds client, and expires in 15 minutes
+func (c *Command) getAuthToken(endpoint *rds.Endpoint) (string, error) {
+	host := net.JoinHostPort(*endpoint.Address, strconv.FormatInt(*endpoint.Port, 10))
+	token, err := rdsutils.BuildAuthToken(host, *c.RDSClient.Config.Region, c.RDSConfig.User, c.RDSClient.Config.Credentials)
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return "", err
+	}
+
+	return token, nil
+}
+
+// authConnector struct is the Command and Endpoint and DBEngine and BaseDriver variable
+// the auth token is generated for each connection, so the connection opened after 15 minutes is not rejected
+type authConnector struct {
+	Command    *Command
+	Endpoint   *rds.Endpoint
+	DBEngine   *dbEngine
+	BaseDriver driver.Driver // the driver registered by the name of engine
+}
+
+// Connect is open the connection with the new auth token as the password
+func (a *authConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	token, err := a.Command.getAuthToken(a.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	authCommand := *a.Command
+	authCommand.RDSConfig.Pass = token
+
+	return a.BaseDriver.Open(a.DBEngine.DataSourceName(&authCommand, a.Endpoint))
+}
+
+// Driver is return the driver registered by the name of engine
+func (a *authConnector) Driver() driver.Driver {
+	return a.BaseDriver
+}
+
+// open db with the auth token of each connection
+func (c *Command) openAuthDB(driverName string, dbEngine *dbEngine, endpoint *rds.Endpoint) (*sql.DB, error) {
+	// sql.Open does not connect, it is used to get the registered driver
+	db, err := sql.Open(driverName, "")
+	if err != nil {
+		return nil, err
+	}
+	baseDriver := db.Driver()
+	db.Close()
+
+	return sql.OpenDB(&authConnector{
+		Command:    c,
+		Endpoint:   endpoint,
+		DBEngine:   dbEngine,
+		BaseDriver: baseDriver,
+	}), nil
+}
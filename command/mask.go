@@ -0,0 +1,99 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/uchimanajet7/rds-try/query"
+)
+
+// masking strategy of column
+const (
+	maskNull     = "null"     // set NULL
+	maskFixed    = "fixed"    // set the fixed value
+	maskHash     = "hash"     // set sha256 of the value
+	maskEmail    = "email"    // set fake email address made from sha256 of the value
+	maskTruncate = "truncate" // keep only the first length characters
+)
+
+const maskEmailDomain = "example.com"
+
+var (
+	// ErrMaskTargetNotFound is the "mask table or column is not specified" error
+	ErrMaskTargetNotFound = errors.New("mask table or column is not specified")
+	// ErrMaskStrategyNotFound is the "mask strategy is not found" error
+	ErrMaskStrategyNotFound = errors.New("mask strategy is not found")
+	// ErrMaskFailed is the "masking failed, queries are not run" error
+	ErrMaskFailed = errors.New("masking failed, queries are not run")
+)
+
+// return UPDATE statements of masking rules
+// the rules are applied in order, so it is also used to check the rules before restore
+func getMaskQueries(masks []query.Mask) ([]query.Query, error) {
+	queries := make([]query.Query, 0, len(masks))
+	for _, mask := range masks {
+		if mask.Table == "" || mask.Column == "" {
+			log.Errorf("%s", ErrMaskTargetNotFound.Error())
+			return nil, ErrMaskTargetNotFound
+		}
+
+		table := quoteMaskIdentifier(mask.Table)
+		column := quoteMaskIdentifier(mask.Column)
+
+		// NULL is kept except "null" and "fixed"
+		value := ""
+		notNull := true
+		switch strings.ToLower(mask.Strategy) {
+		case maskNull:
+			value = "NULL"
+			notNull = false
+		case maskFixed:
+			value = quoteMaskValue(mask.Value)
+			notNull = false
+		case maskHash:
+			value = fmt.Sprintf("SHA2(%s, 256)", column)
+		case maskEmail:
+			value = fmt.Sprintf("CONCAT('user-', LEFT(SHA2(%s, 256), 16), '@%s')", column, maskEmailDomain)
+		case maskTruncate:
+			length := mask.Length
+			if length < 0 {
+				length = 0
+			}
+			value = fmt.Sprintf("LEFT(%s, %d)", column, length)
+		default:
+			log.Errorf("%s: %s", ErrMaskStrategyNotFound.Error(), mask.Strategy)
+			return nil, ErrMaskStrategyNotFound
+		}
+
+		sql := fmt.Sprintf("UPDATE %s SET %s = %s", table, column, value)
+		if notNull {
+			sql += fmt.Sprintf(" WHERE %s IS NOT NULL", column)
+		}
+
+		queries = append(queries, query.Query{
+			Name: fmt.Sprintf("%s.%s (%s)", mask.Table, mask.Column, strings.ToLower(mask.Strategy)),
+			SQL:  sql,
+		})
+	}
+
+	return queries, nil
+}
+
+// ex. "db.account" -> "`db`.`account`"
+func quoteMaskIdentifier(name string) string {
+	var quoted []string
+	for _, item := range strings.Split(name, ".") {
+		quoted = append(quoted, "`"+strings.Replace(item, "`", "``", -1)+"`")
+	}
+
+	return strings.Join(quoted, ".")
+}
+
+// ex. "it's" -> "'it”s'"
+func quoteMaskValue(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, "'", "''", -1)
+
+	return "'" + value + "'"
+}
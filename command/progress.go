@@ -0,0 +1,186 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// progress struct is the current phase and elapsed time and latest status and ended phases of es variable
+// all methods can be called with nil, and nothing is done
+type progress struct {
+	out        io.Writer
+	tty        bool
+	mutex      sync.Mutex
+	phase      string
+	phaseStart time.Time
+	status     string
+	phases     []phaseResult
+	done       chan struct{}
+}
+
+// phaseResult struct is the Name and Time of ended phase variable
+type phaseResult struct {
+	Name string
+	Time time.Duration
+}
+
+// 1 second intervals redrawn
+const progressInterval = time.Second
+
+// return progress shown to out
+// when out is not terminal, phases are shown by log lines
+func newProgress(out *os.File) *progress {
+	p := &progress{
+		out: out,
+		tty: isTerminal(out),
+	}
+
+	if p.tty {
+		// the log lines are replaced by progress view
+		log.SetCLIQuiet(true)
+
+		p.done = make(chan struct{})
+		go p.redraw(p.done)
+	}
+
+	return p
+}
+
+// check character device such as terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// end current phase and start the phase
+func (p *progress) setPhase(phase string) {
+	if p == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	p.endPhase()
+	p.phase = phase
+	p.phaseStart = time.Now()
+	p.status = ""
+	if !p.tty {
+		log.Infof("phase start: %s", phase)
+	}
+	p.mutex.Unlock()
+
+	p.draw()
+}
+
+// set latest rds status of current phase
+func (p *progress) setStatus(status string) {
+	if p == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	p.status = status
+	p.mutex.Unlock()
+
+	p.draw()
+}
+
+// end current phase and stop redrawing
+// it can be called more than once
+func (p *progress) stop() {
+	if p == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.endPhase()
+	p.phase = ""
+
+	if p.done != nil {
+		close(p.done)
+		p.done = nil
+		log.SetCLIQuiet(false)
+	}
+}
+
+// need to run the caller always "p.mutex.Lock()"
+func (p *progress) endPhase() {
+	if p.phase == "" {
+		return
+	}
+
+	p.phases = append(p.phases, phaseResult{
+		Name: p.phase,
+		Time: time.Now().Sub(p.phaseStart),
+	})
+
+	elapsed := getElapsedText(time.Now().Sub(p.phaseStart))
+	if p.tty {
+		fmt.Fprintf(p.out, "\r\033[K[%s] %s done\n", elapsed, p.phase)
+	} else {
+		log.Infof("phase end: %s (%s)", p.phase, elapsed)
+	}
+}
+
+// return the ended phases in order
+func (p *progress) getPhases() []phaseResult {
+	if p == nil {
+		return nil
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]phaseResult(nil), p.phases...)
+}
+
+// show current phase and elapsed time and latest status on one line
+func (p *progress) draw() {
+	if !p.tty {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.phase == "" {
+		return
+	}
+
+	statusText := ""
+	if p.status != "" {
+		statusText = ", status: " + p.status
+	}
+	elapsed := getElapsedText(time.Now().Sub(p.phaseStart))
+	fmt.Fprintf(p.out, "\r\033[K[%s] %s%s", elapsed, p.phase, statusText)
+}
+
+// redraw for elapsed time until done is closed
+func (p *progress) redraw(done <-chan struct{}) {
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.draw()
+		case <-done:
+			return
+		}
+	}
+}
+
+// return elapsed time text
+// ex. "01:02:03"
+func getElapsedText(elapsed time.Duration) string {
+	total := int(elapsed.Seconds())
+	return fmt.Sprintf("%02d:%02d:%02d", total/3600, total%3600/60, total%60)
+}
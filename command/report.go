@@ -0,0 +1,254 @@
+package command
+
+import (
+	"bytes"
+	"errors"
+	htmltemplate "html/template"
+	"io/ioutil"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/uchimanajet7/rds-try/utils"
+)
+
+// format of report file
+const (
+	reportMarkdown = "md"
+	reportHTML     = "html"
+)
+
+var (
+	// ErrReportFormatNotFound is the "report format is not found" error
+	ErrReportFormatNotFound = errors.New("report format is not found")
+)
+
+// reportData struct is the source and restore settings and phases and query runs of report variable
+type reportData struct {
+	Title           string
+	EnvName         string
+	Region          string
+	SourceID        string
+	SnapshotID      string
+	SnapshotAge     string
+	DBInstanceClass string
+	Phases          []reportPhase
+	Runs            []reportRun
+}
+
+// reportPhase struct is the Name and Time of phase variable
+type reportPhase struct {
+	Name string
+	Time string
+}
+
+// reportRun struct is the DBIdentifier and EngineVersion and Queries of run variable
+type reportRun struct {
+	DBIdentifier  string
+	EngineVersion string
+	Queries       []reportQuery
+}
+
+// reportQuery struct is the Name and Time and Rows and Checksum and File and Percent of query variable
+// Percent is the ratio to the longest query time in the run, used for bar chart
+type reportQuery struct {
+	Name     string
+	Time     string
+	Rows     int64
+	Checksum string
+	File     string
+	Percent  float64
+}
+
+const markdownReportTemplate = `# {{.Title}}
+
+## restore settings
+
+| item | value |
+| --- | --- |
+| environment | {{cell .EnvName}} |
+| region | {{cell .Region}} |
+| source db instance | {{cell .SourceID}} |
+| db snapshot | {{cell .SnapshotID}} |
+| snapshot age | {{cell .SnapshotAge}} |
+| instance class | {{cell .DBInstanceClass}} |
+{{range .Runs}}| restored db instance | {{cell .DBIdentifier}} ({{cell .EngineVersion}}) |
+{{end}}
+## phases
+
+| phase | time |
+| --- | --- |
+{{range .Phases}}| {{cell .Name}} | {{.Time}} |
+{{end}}{{range .Runs}}
+## queries: {{.DBIdentifier}} ({{.EngineVersion}})
+
+| query | time | rows | sha256 | output file |
+| --- | --- | --- | --- | --- |
+{{range .Queries}}| {{cell .Name}} | {{.Time}} | {{.Rows}} | {{if .Checksum}}{{.Checksum}}{{else}}-{{end}} | {{if .File}}[{{cell .File}}]({{cell .File}}){{else}}-{{end}} |
+{{end}}{{end}}`
+
+const htmlReportTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+th { background: #f0f0f0; }
+.bar { background: #4a90d9; height: 1em; min-width: 1px; }
+.chart { width: 300px; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<h2>restore settings</h2>
+<table>
+<tr><th>environment</th><td>{{.EnvName}}</td></tr>
+<tr><th>region</th><td>{{.Region}}</td></tr>
+<tr><th>source db instance</th><td>{{.SourceID}}</td></tr>
+<tr><th>db snapshot</th><td>{{.SnapshotID}}</td></tr>
+<tr><th>snapshot age</th><td>{{.SnapshotAge}}</td></tr>
+<tr><th>instance class</th><td>{{.DBInstanceClass}}</td></tr>
+{{range .Runs}}<tr><th>restored db instance</th><td>{{.DBIdentifier}} ({{.EngineVersion}})</td></tr>
+{{end}}</table>
+<h2>phases</h2>
+<table>
+<tr><th>phase</th><th>time</th></tr>
+{{range .Phases}}<tr><td>{{.Name}}</td><td>{{.Time}}</td></tr>
+{{end}}</table>
+{{range .Runs}}<h2>queries: {{.DBIdentifier}} ({{.EngineVersion}})</h2>
+<table>
+<tr><th>query</th><th>time</th><th>rows</th><th>sha256</th><th>output file</th><th class="chart"></th></tr>
+{{range .Queries}}<tr><td>{{.Name}}</td><td>{{.Time}}</td><td>{{.Rows}}</td><td>{{if .Checksum}}{{.Checksum}}{{else}}-{{end}}</td><td>{{if .File}}<a href="{{.File}}">{{.File}}</a>{{else}}-{{end}}</td><td class="chart"><div class="bar" style="width: {{printf "%.1f" .Percent}}%"></div></td></tr>
+{{end}}</table>
+{{end}}</body>
+</html>
+`
+
+// the table cell of markdown is broken by "|" and new line
+// ex. "a|b\nc" -> "a\\|b<br>c"
+var markdownCellReplacer = strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+func escapeMarkdownCell(text string) string {
+	return markdownCellReplacer.Replace(text)
+}
+
+// check the format of report file
+func isReportFormat(format string) bool {
+	return format == reportMarkdown || format == reportHTML
+}
+
+// write report file of summary to the same directory as csv file
+// return the path of written file
+func (c *EsCommand) writeReportFile(summary *esSummary, format string) (string, error) {
+	text, err := summary.getReport(format, time.Now())
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return "", err
+	}
+
+	outPath := utils.GetHomeDir()
+	if c.OutConfig.Root != "" {
+		outPath = c.OutConfig.Root
+	}
+	fileName := utils.GetAppName() + "-report-" + utils.GetFormatedTime() + "." + format
+	outPath = path.Join(outPath, fileName)
+
+	err = ioutil.WriteFile(outPath, []byte(text), 0644)
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return "", err
+	}
+
+	return outPath, nil
+}
+
+// return report text of summary in format
+// the snapshot age is measured at now
+func (s *esSummary) getReport(format string, now time.Time) (string, error) {
+	data := s.getReportData(now)
+
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case reportMarkdown:
+		tmpl := template.Must(template.New("report").Funcs(template.FuncMap{"cell": escapeMarkdownCell}).Parse(markdownReportTemplate))
+		err = tmpl.Execute(&buf, data)
+	case reportHTML:
+		tmpl := htmltemplate.Must(htmltemplate.New("report").Parse(htmlReportTemplate))
+		err = tmpl.Execute(&buf, data)
+	default:
+		return "", ErrReportFormatNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func (s *esSummary) getReportData(now time.Time) *reportData {
+	data := &reportData{
+		Title:           utils.GetAppName() + " report " + now.Format("2006-01-02 15:04:05"),
+		EnvName:         s.EnvName,
+		Region:          s.Region,
+		SourceID:        s.SourceID,
+		SnapshotID:      s.SnapshotID,
+		SnapshotAge:     "-",
+		DBInstanceClass: s.DBInstanceClass,
+	}
+	if s.SnapshotTime != nil {
+		data.SnapshotAge = now.Sub(*s.SnapshotTime).Truncate(time.Second).String()
+	}
+	// read replica has the latest data of source db instance
+	if s.Replica {
+		data.SnapshotID = "- (read replica)"
+	}
+
+	for _, phase := range s.Phases {
+		data.Phases = append(data.Phases, reportPhase{
+			Name: phase.Name,
+			Time: getElapsedText(phase.Time),
+		})
+	}
+
+	for _, run := range s.Runs {
+		var maxTime time.Duration
+		for _, t := range run.Times {
+			if t > maxTime {
+				maxTime = t
+			}
+		}
+
+		rRun := reportRun{
+			DBIdentifier:  run.DBIdentifier,
+			EngineVersion: run.EngineVersion,
+		}
+		for i, t := range run.Times {
+			rQuery := reportQuery{
+				Name: run.Queries[i].Name,
+				Time: t.String(),
+			}
+			if i < len(run.Rows) {
+				rQuery.Rows = run.Rows[i]
+			}
+			if i < len(run.Checksums) {
+				rQuery.Checksum = run.Checksums[i]
+			}
+			// the report is written to the same directory as csv file
+			if i < len(run.Files) && run.Files[i] != "" {
+				rQuery.File = path.Base(run.Files[i])
+			}
+			if maxTime > 0 {
+				rQuery.Percent = float64(t) / float64(maxTime) * 100
+			}
+			rRun.Queries = append(rRun.Queries, rQuery)
+		}
+		data.Runs = append(data.Runs, rRun)
+	}
+
+	return data
+}
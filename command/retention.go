@@ -0,0 +1,186 @@
+package command
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+var (
+	// ErrRetentionNotFound is the "retention_count or retention_days is not specified" error
+	ErrRetentionNotFound = errors.New("retention_count or retention_days is not specified")
+)
+
+// HasRetention is check retention policy of snap shot is specified
+func (c *Command) HasRetention() bool {
+	return c.RDSConfig.RetentionCount > 0 || c.RDSConfig.RetentionDays > 0
+}
+
+// the snap shot is kept if it is one of the latest "retention_count" or younger than "retention_days"
+// index is the order from the latest
+func (c *Command) isOverRetention(index int, createTime time.Time, now time.Time) bool {
+	if !c.HasRetention() {
+		return false
+	}
+	if c.RDSConfig.RetentionCount > 0 && index < c.RDSConfig.RetentionCount {
+		return false
+	}
+	if c.RDSConfig.RetentionDays > 0 && now.Sub(createTime) < time.Duration(c.RDSConfig.RetentionDays)*24*time.Hour {
+		return false
+	}
+
+	return true
+}
+
+// only "available" db snapshots of "db_id" over the retention policy are the target
+// the snapshot still creating is kept and not counted
+// snapList is db snapshots created in this tool
+func (c *Command) filterRetentionDBSnapshots(snapList []*rds.DBSnapshot, now time.Time) []*rds.DBSnapshot {
+	var ownList []*rds.DBSnapshot
+	for _, snap := range snapList {
+		if snap.DBInstanceIdentifier == nil || *snap.DBInstanceIdentifier != c.RDSConfig.DBId {
+			continue
+		}
+		if snap.Status == nil || *snap.Status != "available" || snap.SnapshotCreateTime == nil {
+			continue
+		}
+		ownList = append(ownList, snap)
+	}
+
+	// latest first
+	sort.SliceStable(ownList, func(i, j int) bool {
+		return ownList[i].SnapshotCreateTime.After(*ownList[j].SnapshotCreateTime)
+	})
+
+	var overList []*rds.DBSnapshot
+	for i, snap := range ownList {
+		if c.isOverRetention(i, *snap.SnapshotCreateTime, now) {
+			overList = append(overList, snap)
+		}
+	}
+
+	return overList
+}
+
+// only "available" db cluster snapshots of "db_id" over the retention policy are the target
+// the snapshot still creating is kept and not counted
+// snapList is db cluster snapshots created in this tool
+func (c *Command) filterRetentionDBClusterSnapshots(snapList []*rds.DBClusterSnapshot, now time.Time) []*rds.DBClusterSnapshot {
+	var ownList []*rds.DBClusterSnapshot
+	for _, snap := range snapList {
+		if snap.DBClusterIdentifier == nil || *snap.DBClusterIdentifier != c.RDSConfig.DBId {
+			continue
+		}
+		if snap.Status == nil || *snap.Status != "available" || snap.SnapshotCreateTime == nil {
+			continue
+		}
+		ownList = append(ownList, snap)
+	}
+
+	// latest first
+	sort.SliceStable(ownList, func(i, j int) bool {
+		return ownList[i].SnapshotCreateTime.After(*ownList[j].SnapshotCreateTime)
+	})
+
+	var overList []*rds.DBClusterSnapshot
+	for i, snap := range ownList {
+		if c.isOverRetention(i, *snap.SnapshotCreateTime, now) {
+			overList = append(overList, snap)
+		}
+	}
+
+	return overList
+}
+
+// DescribeOverRetentionSnapshots is show db snapshots and db cluster snapshots over the retention policy
+// the snapshots of excludes and the snapshots restored by this tool are kept
+// excludes is the identifiers of snapshots, ex. created by the running es
+func (c *Command) DescribeOverRetentionSnapshots(excludes []string) ([]*rds.DBSnapshot, []*rds.DBClusterSnapshot, error) {
+	now := time.Now()
+
+	snapList, err := c.DescribeDBSnapshotsByTags()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clusterSnapList, err := c.DescribeDBClusterSnapshotsByTags()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keeps, err := c.describeRestoredSnapshots()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, id := range excludes {
+		keeps[id] = true
+	}
+
+	return excludeDBSnapshots(c.filterRetentionDBSnapshots(snapList, now), keeps),
+		excludeDBClusterSnapshots(c.filterRetentionDBClusterSnapshots(clusterSnapList, now), keeps), nil
+}
+
+// return the identifiers of rt_snapshot tag of db instances and db clusters created in this tool
+// the snapshot is kept while restoring by the es of other environment, and while the restored db exists
+func (c *Command) describeRestoredSnapshots() (map[string]bool, error) {
+	dbList, err := c.DescribeDBInstancesByTags()
+	if err != nil {
+		return nil, err
+	}
+
+	clusterList, err := c.DescribeDBClustersByTags()
+	if err != nil {
+		return nil, err
+	}
+
+	var restoredList []interface{}
+	for _, db := range dbList {
+		restoredList = append(restoredList, db)
+	}
+	for _, cluster := range clusterList {
+		restoredList = append(restoredList, cluster)
+	}
+
+	restored := make(map[string]bool)
+	for _, item := range restoredList {
+		tagList, err := c.listTagsForResource(item)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tagList {
+			if *tag.Key == rtSnapshotText {
+				restored[*tag.Value] = true
+			}
+		}
+	}
+
+	return restored, nil
+}
+
+func excludeDBSnapshots(snapList []*rds.DBSnapshot, keeps map[string]bool) []*rds.DBSnapshot {
+	var result []*rds.DBSnapshot
+	for _, snap := range snapList {
+		if keeps[*snap.DBSnapshotIdentifier] {
+			log.Infof("keep DB Snapshot in use: %s", *snap.DBSnapshotIdentifier)
+			continue
+		}
+		result = append(result, snap)
+	}
+
+	return result
+}
+
+func excludeDBClusterSnapshots(snapList []*rds.DBClusterSnapshot, keeps map[string]bool) []*rds.DBClusterSnapshot {
+	var result []*rds.DBClusterSnapshot
+	for _, snap := range snapList {
+		if keeps[*snap.DBClusterSnapshotIdentifier] {
+			log.Infof("keep DB Cluster Snapshot in use: %s", *snap.DBClusterSnapshotIdentifier)
+			continue
+		}
+		result = append(result, snap)
+	}
+
+	return result
+}
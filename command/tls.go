@@ -0,0 +1,182 @@
+package command
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/uchimanajet7/rds-try/utils"
+)
+
+// ssl mode of db connection, the same as sslmode of postgres
+const (
+	sslModeDisable    = "disable"     // not encrypted
+	sslModeRequire    = "require"     // encrypted without verification
+	sslModeVerifyCA   = "verify-ca"   // verify the certificate by "ssl_ca"
+	sslModeVerifyFull = "verify-full" // verify the certificate by "ssl_ca" and the server name
+)
+
+var (
+	// ErrSSLModeNotSupported is the "ssl_mode is not supported" error
+	ErrSSLModeNotSupported = errors.New("ssl_mode is not supported")
+	// ErrSSLCertificateNotFound is the "certificate is not found in ssl_ca file" error
+	ErrSSLCertificateNotFound = errors.New("certificate is not found in ssl_ca file")
+	// ErrSSLModeNotSupportedByEngine is the "ssl_mode is not supported by DB engine" error
+	ErrSSLModeNotSupportedByEngine = errors.New("ssl_mode is not supported by DB engine")
+	// ErrSSLCANotSupported is the "ssl_ca is not supported by DB engine" error
+	ErrSSLCANotSupported = errors.New("ssl_ca is not supported by DB engine")
+	// ErrSSLServerNameNotSupported is the "ssl_server_name is not supported by DB engine" error
+	ErrSSLServerNameNotSupported = errors.New("ssl_server_name is not supported by DB engine")
+)
+
+// CheckSSLMode is check "ssl_mode" is supported
+// empty is the default of each engine
+func (c *Command) CheckSSLMode() error {
+	switch c.RDSConfig.SSLMode {
+	case "", sslModeDisable, sslModeRequire, sslModeVerifyCA, sslModeVerifyFull:
+		return nil
+	}
+
+	log.Errorf("%s: %s", ErrSSLModeNotSupported.Error(), c.RDSConfig.SSLMode)
+	return ErrSSLModeNotSupported
+}
+
+// the settings ignored by the engine are rejected before restore
+// empty "ssl_mode" is the default of each engine
+func (c *Command) checkSSLSettings(dbEngine *dbEngine) error {
+	if c.RDSConfig.SSLMode != "" && !dbEngine.hasSSLMode(c.RDSConfig.SSLMode) {
+		return ErrSSLModeNotSupportedByEngine
+	}
+	// "ssl_ca" is used only to verify the certificate
+	if c.RDSConfig.SSLCA != "" && !dbEngine.hasSSLMode(sslModeVerifyCA) && !dbEngine.hasSSLMode(sslModeVerifyFull) {
+		return ErrSSLCANotSupported
+	}
+	if c.RDSConfig.SSLServerName != "" && !dbEngine.SSLServerName {
+		return ErrSSLServerNameNotSupported
+	}
+
+	return nil
+}
+
+func (e *dbEngine) hasSSLMode(mode string) bool {
+	for _, m := range e.SSLModes {
+		if m == mode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mysql is not encrypted if "ssl_mode" is not specified
+// iam database authentication is always encrypted
+func (c *Command) isMySQLTLS() bool {
+	if c.RDSConfig.IAMAuth {
+		return true
+	}
+
+	return c.RDSConfig.SSLMode != "" && c.RDSConfig.SSLMode != sslModeDisable
+}
+
+// the key of tls config registered to mysql driver, one per rds environment
+// ex. "rds-try-default"
+func (c *Command) getMySQLTLSConfigName() string {
+	return utils.GetAppName() + "-" + c.Name
+}
+
+// register tls config of "ssl_mode" to mysql driver
+// need to be called before the connection
+func (c *Command) registerMySQLTLSConfig(endpoint *rds.Endpoint) error {
+	if !c.isMySQLTLS() {
+		return nil
+	}
+
+	tlsConfig, err := c.getTLSConfig(endpoint)
+	if err != nil {
+		return err
+	}
+
+	if err := mysql.RegisterTLSConfig(c.getMySQLTLSConfigName(), tlsConfig); err != nil {
+		log.Errorf("%s", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// the certificate is verified by the system roots if "ssl_ca" is not specified
+// the server name is the endpoint address if "ssl_server_name" is not specified
+func (c *Command) getTLSConfig(endpoint *rds.Endpoint) (*tls.Config, error) {
+	if err := c.CheckSSLMode(); err != nil {
+		return nil, err
+	}
+	// "require" if "ssl_mode" is not specified with iam database authentication
+	if c.RDSConfig.SSLMode == sslModeRequire || c.RDSConfig.SSLMode == "" {
+		return &tls.Config{InsecureSkipVerify: true}, nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName: c.RDSConfig.SSLServerName,
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = *endpoint.Address
+	}
+
+	if c.RDSConfig.SSLCA != "" {
+		pem, err := ioutil.ReadFile(c.RDSConfig.SSLCA)
+		if err != nil {
+			log.Errorf("%s", err.Error())
+			return nil, err
+		}
+
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			log.Errorf("%s: %s", ErrSSLCertificateNotFound.Error(), c.RDSConfig.SSLCA)
+			return nil, ErrSSLCertificateNotFound
+		}
+		tlsConfig.RootCAs = roots
+	}
+
+	// the server name is not verified, so verify only the certificate chain
+	if c.RDSConfig.SSLMode == sslModeVerifyCA {
+		roots := tlsConfig.RootCAs
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifyCertificateChain(rawCerts, roots)
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+// the first certificate is the server, and others are the intermediates
+func verifyCertificateChain(rawCerts [][]byte, roots *x509.CertPool) error {
+	if len(rawCerts) <= 0 {
+		return ErrSSLCertificateNotFound
+	}
+
+	intermediates := x509.NewCertPool()
+	var server *x509.Certificate
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			server = cert
+			continue
+		}
+		intermediates.AddCert(cert)
+	}
+
+	_, err := server.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+
+	return err
+}
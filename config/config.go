@@ -1,118 +1,162 @@
-package config
-
-import (
-	"errors"
-	"path"
-
-	"github.com/BurntSushi/toml"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/credentials/ec2rolecreds"
-
-	"github.com/uchimanajet7/rds-try/logger"
-	"github.com/uchimanajet7/rds-try/utils"
-)
-
-// Config struct is Aws AWSConfig and Out OutConfig and Rds map and Log LogConfig variable
-type Config struct {
-	Aws AWSConfig
-	Out OutConfig
-	Rds map[string]RDSConfig
-	Log LogConfig
-}
-
-// AWSConfig struct is Accesskey and SecretKey variable
-type AWSConfig struct {
-	Accesskey string `toml:"access_key"`
-	SecretKey string `toml:"secret_key"`
-}
-
-// OutConfig struct is Root and File and Bom variable
-type OutConfig struct {
-	Root string `toml:"root"`
-	File bool   `toml:"file"`
-	Bom  bool   `toml:"bom"`
-}
-
-// LogConfig struct is Root and Verbose and JSON variable
-type LogConfig struct {
-	Root    string `toml:"root"`
-	Verbose bool   `toml:"verbose"`
-	JSON    bool   `toml:"json"`
-}
-
-// RDSConfig struct is MultiAz and DBId and Region and User and Pass and Type variable
-type RDSConfig struct {
-	MultiAz bool   `toml:"multi_az"`
-	DBId    string `toml:"db_id"`
-	Region  string `toml:"region"`
-	User    string `toml:"user"`
-	Pass    string `toml:"pass"`
-	Type    string `toml:"type"`
-}
-
-const configFile = "rds-try.conf"
-
-var log = logger.GetLogger("config")
-
-// ErrRdsSectionNotFound is the config file format error
-var ErrRdsSectionNotFound = errors.New("[rds.*] section not found in file")
-
-// LoadConfig is the contents are loaded from "rds-try.conf" file.
-func LoadConfig(file string) (*Config, error) {
-	config := &Config{}
-
-	if _, err := toml.DecodeFile(file, &config); err != nil {
-		log.Errorf("%s", err.Error())
-		return config, err
-	}
-
-	if len(config.Rds) <= 0 {
-		log.Errorf("%s", ErrRdsSectionNotFound.Error())
-		return nil, ErrRdsSectionNotFound
-	}
-	log.Debugf("Config: %+v", config)
-
-	return config, nil
-}
-
-// GetDefaultPath is return default conf file path.
-func GetDefaultPath() string {
-	return path.Join(utils.GetHomeDir(), configFile)
-}
-
-// GetAWSCreds returns the appropriate value as the need arises.
-//
-// evaluated in the following order
-// 1. input variable
-// 2. Environment variable
-// 3. IAM Role
-//
-// "/.aws/credentials" necessary item increased about that, so it isn't used.
-func (c *Config) GetAWSCreds() (*credentials.Credentials, error) {
-	var creds *credentials.Credentials
-	var err error
-
-	err = nil
-	// 1. input variable used
-	if c.Aws.Accesskey != "" && c.Aws.SecretKey != "" {
-		creds = credentials.NewStaticCredentials(c.Aws.Accesskey, c.Aws.SecretKey, "")
-		creds.Expire()
-		_, err = creds.Get()
-	}
-
-	if err != nil {
-		// 2. Environment variable used
-		creds = credentials.NewEnvCredentials()
-		creds.Expire()
-		_, err = creds.Get()
-
-		if err != nil {
-			// 3. IAM Role used
-			creds = credentials.NewCredentials(&ec2rolecreds.EC2RoleProvider{})
-			creds.Expire()
-			_, err = creds.Get()
-		}
-	}
-
-	return creds, err
-}
+package config
+
+import (
+	"errors"
+	"path"
+
+	"github.com/BurntSushi/toml"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/ec2rolecreds"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	"github.com/uchimanajet7/rds-try/logger"
+	"github.com/uchimanajet7/rds-try/query"
+	"github.com/uchimanajet7/rds-try/utils"
+)
+
+// Config struct is Aws AWSConfig and Out OutConfig and Rds map and Log LogConfig and Notify NotifyConfig and Schedule array variable
+type Config struct {
+	Aws      AWSConfig
+	Out      OutConfig
+	Rds      map[string]RDSConfig
+	Log      LogConfig
+	Notify   NotifyConfig
+	Schedule []ScheduleConfig
+}
+
+// AWSConfig struct is Accesskey and SecretKey variable
+type AWSConfig struct {
+	Accesskey string `toml:"access_key"`
+	SecretKey string `toml:"secret_key"`
+}
+
+// OutConfig struct is Root and File and Bom variable
+type OutConfig struct {
+	Root string `toml:"root"`
+	File bool   `toml:"file"`
+	Bom  bool   `toml:"bom"`
+}
+
+// LogConfig struct is Root and Verbose and JSON variable
+type LogConfig struct {
+	Root    string `toml:"root"`
+	Verbose bool   `toml:"verbose"`
+	JSON    bool   `toml:"json"`
+}
+
+// NotifyConfig struct is URLs and Format and Retry variable
+type NotifyConfig struct {
+	URLs   []string `toml:"urls"`
+	Format string   `toml:"format"` // "json" or "slack", "json" if not specified
+	Retry  int      `toml:"retry"`
+}
+
+// ScheduleConfig struct is Cron and Name and Query and Options variable
+type ScheduleConfig struct {
+	Cron    string   `toml:"cron"`
+	Name    string   `toml:"name"`    // rds environment name, "default" if not specified
+	Query   string   `toml:"query"`   // query file, default query file if not specified
+	Options []string `toml:"options"` // other es options
+}
+
+// RDSConfig struct is MultiAz and DBId and Region and User and Pass and DBName and SSL settings and IAMAuth and ServiceName and SID and Type and Storage settings and Network settings and CopyRegion and Snapshot settings and KmsKeyID and TTL and OnInterrupt and Mask and Retention settings variable
+type RDSConfig struct {
+	MultiAz          bool         `toml:"multi_az"`
+	DBId             string       `toml:"db_id"`
+	Region           string       `toml:"region"`
+	User             string       `toml:"user"`
+	Pass             string       `toml:"pass"`
+	DBName           string       `toml:"db_name"`         // database to connect, "postgres" for postgres if not specified
+	SSLMode          string       `toml:"ssl_mode"`        // "require" for postgres and not encrypted for mysql if not specified
+	SSLCA            string       `toml:"ssl_ca"`          // ca bundle file to verify the certificate
+	SSLServerName    string       `toml:"ssl_server_name"` // server name to verify, endpoint address if not specified
+	IAMAuth          bool         `toml:"iam_auth"`        // auth token is used rather than pass
+	ServiceName      string       `toml:"service_name"`    // service name of oracle, used rather than SID
+	SID              string       `toml:"sid"`             // SID of oracle, "ORCL" if both are not specified
+	Type             string       `toml:"type"`
+	StorageType      string       `toml:"storage_type"`
+	Iops             int64        `toml:"iops"`
+	AllocatedStorage int64        `toml:"allocated_storage"`
+	SubnetGroup      string       `toml:"subnet_group"`
+	ParameterGroup   string       `toml:"parameter_group"`
+	SecurityGroups   []string     `toml:"security_groups"`
+	CopyRegion       string       `toml:"copy_region"`
+	SnapshotType     string       `toml:"snapshot_type"`
+	SnapshotARN      string       `toml:"snapshot_arn"`
+	KmsKeyID         string       `toml:"kms_key_id"`
+	TTL              string       `toml:"ttl"`
+	OnInterrupt      string       `toml:"on_interrupt"`
+	Mask             []query.Mask `toml:"mask"`            // applied before the masking rules of query file
+	RetentionCount   int          `toml:"retention_count"` // keep the latest snapshots of this count
+	RetentionDays    int          `toml:"retention_days"`  // keep the snapshots younger than these days
+}
+
+const configFile = "rds-try.conf"
+
+var log = logger.GetLogger("config")
+
+// ErrRdsSectionNotFound is the config file format error
+var ErrRdsSectionNotFound = errors.New("[rds.*] section not found in file")
+
+// LoadConfig is the contents are loaded from "rds-try.conf" file.
+func LoadConfig(file string) (*Config, error) {
+	config := &Config{}
+
+	if _, err := toml.DecodeFile(file, &config); err != nil {
+		log.Errorf("%s", err.Error())
+		return config, err
+	}
+
+	if len(config.Rds) <= 0 {
+		log.Errorf("%s", ErrRdsSectionNotFound.Error())
+		return nil, ErrRdsSectionNotFound
+	}
+	log.Debugf("Config: %+v", config)
+
+	return config, nil
+}
+
+// GetDefaultPath is return default conf file path.
+func GetDefaultPath() string {
+	return path.Join(utils.GetHomeDir(), configFile)
+}
+
+// GetAWSCreds returns the appropriate value as the need arises.
+//
+// evaluated in the following order
+// 1. input variable
+// 2. Environment variable
+// 3. IAM Role
+//
+// "/.aws/credentials" necessary item increased about that, so it isn't used.
+func (c *Config) GetAWSCreds() (*credentials.Credentials, error) {
+	var creds *credentials.Credentials
+	var err error
+
+	err = nil
+	// 1. input variable used
+	if c.Aws.Accesskey != "" && c.Aws.SecretKey != "" {
+		creds = credentials.NewStaticCredentials(c.Aws.Accesskey, c.Aws.SecretKey, "")
+		creds.Expire()
+		_, err = creds.Get()
+	}
+
+	if err != nil {
+		// 2. Environment variable used
+		creds = credentials.NewEnvCredentials()
+		creds.Expire()
+		_, err = creds.Get()
+
+		if err != nil {
+			// 3. IAM Role used
+			creds = credentials.NewCredentials(&ec2rolecreds.EC2RoleProvider{
+				Client: ec2metadata.New(session.New()),
+			})
+			creds.Expire()
+			_, err = creds.Get()
+		}
+	}
+
+	return creds, err
+}
@@ -0,0 +1,203 @@
+package cron
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule struct is the matched values of minute and hour and day of month and month and day of week variable
+// each field is the bit set of matched values
+type Schedule struct {
+	minute   uint64
+	hour     uint64
+	dom      uint64
+	month    uint64
+	dow      uint64
+	domStar  bool
+	dowStar  bool
+	spec     string
+	location *time.Location
+}
+
+// bounds struct is the Min and Max of field value variable
+type bounds struct {
+	Min int
+	Max int
+}
+
+var (
+	minuteBounds = bounds{0, 59}
+	hourBounds   = bounds{0, 23}
+	domBounds    = bounds{1, 31}
+	monthBounds  = bounds{1, 12}
+	// 7 is also sunday
+	dowBounds = bounds{0, 7}
+)
+
+// the same as crontab
+var shortcuts = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Next is given up after this period
+const maxSearchYears = 5
+
+var (
+	// ErrInvalidSpec is "invalid cron spec" error.
+	ErrInvalidSpec = errors.New("invalid cron spec")
+)
+
+// Parse is return Schedule of cron spec in local time.
+//
+// spec is 5 fields "minute hour day-of-month month day-of-week"
+// each field is "*", value, range "1-5", step "*/15" or "1-30/5" and list "1,15"
+// "@hourly", "@daily", "@weekly", "@monthly" and "@yearly" are also available
+func Parse(spec string) (*Schedule, error) {
+	text := strings.TrimSpace(spec)
+	if value, ok := shortcuts[text]; ok {
+		text = value
+	}
+
+	fields := strings.Fields(text)
+	if len(fields) != 5 {
+		return nil, ErrInvalidSpec
+	}
+
+	s := &Schedule{
+		spec:     spec,
+		location: time.Local,
+	}
+	var err error
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, err
+	}
+	// 7 is the same as 0
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = fields[2] == "*"
+	s.dowStar = fields[4] == "*"
+
+	return s, nil
+}
+
+// String is return the original spec.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Match is return true if the minute of t is matched.
+// when both day of month and day of week are restricted, either is matched as crontab
+func (s *Schedule) Match(t time.Time) bool {
+	t = t.In(s.location)
+
+	return s.minute&(1<<uint(t.Minute())) != 0 &&
+		s.hour&(1<<uint(t.Hour())) != 0 &&
+		s.month&(1<<uint(t.Month())) != 0 &&
+		s.matchDay(t)
+}
+
+// Next is return the first matched minute after t.
+// zero time is returned if not matched within 5 years, for example "0 0 30 2 *"
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) matchDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+// ex. "*", "5", "1-5", "*/15", "1-30/5", "1,15,30"
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		rangeText := item
+		step := 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			rangeText = item[:i]
+			value, err := strconv.Atoi(item[i+1:])
+			if err != nil || value <= 0 {
+				return 0, ErrInvalidSpec
+			}
+			step = value
+		}
+
+		start, end := b.Min, b.Max
+		if rangeText != "*" {
+			values := strings.SplitN(rangeText, "-", 2)
+			var err error
+			if start, err = strconv.Atoi(values[0]); err != nil {
+				return 0, ErrInvalidSpec
+			}
+			end = start
+			if len(values) > 1 {
+				if end, err = strconv.Atoi(values[1]); err != nil {
+					return 0, ErrInvalidSpec
+				}
+			} else if step > 1 {
+				// "5/15" is from 5 to max
+				end = b.Max
+			}
+		}
+		if start < b.Min || end > b.Max || start > end {
+			return 0, ErrInvalidSpec
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, nil
+}
@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	for _, spec := range []string{"* * * * *", "0 2 * * *", "*/15 9-18 * * 1-5", "0,30 1-23/2 1,15 * 7", "@daily", "5/10 * * * *"} {
+		if _, err := Parse(spec); err != nil {
+			t.Errorf("[Parse] result error: %s %s", spec, err.Error())
+		}
+	}
+
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@every"} {
+		if _, err := Parse(spec); err != ErrInvalidSpec {
+			t.Errorf("[Parse] error not match: %s %v", spec, err)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	s, _ := Parse("*/15 9-18 * * 1-5")
+	s.location = time.UTC
+
+	// 2015-02-25 is wednesday
+	if !s.Match(time.Date(2015, 2, 25, 9, 30, 0, 0, time.UTC)) {
+		t.Error("schedule must be matched at weekday 9:30")
+	}
+	if s.Match(time.Date(2015, 2, 25, 9, 31, 0, 0, time.UTC)) {
+		t.Error("schedule must not be matched at 9:31")
+	}
+	if s.Match(time.Date(2015, 2, 28, 9, 30, 0, 0, time.UTC)) {
+		t.Error("schedule must not be matched at saturday")
+	}
+
+	// either day of month or day of week is matched when both are restricted
+	s, _ = Parse("0 0 1 * 7")
+	s.location = time.UTC
+	if !s.Match(time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC)) || !s.Match(time.Date(2015, 2, 22, 0, 0, 0, 0, time.UTC)) {
+		t.Error("schedule must be matched at 1st day or sunday")
+	}
+}
+
+func TestNext(t *testing.T) {
+	s, _ := Parse("@daily")
+	s.location = time.UTC
+
+	now := time.Date(2015, 2, 25, 12, 34, 56, 0, time.UTC)
+	next := s.Next(now)
+	if !next.Equal(time.Date(2015, 2, 26, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("next time not match: %s", next)
+	}
+
+	// the matched minute itself is not returned
+	s, _ = Parse("30 2 * * *")
+	s.location = time.UTC
+	next = s.Next(time.Date(2015, 2, 25, 2, 30, 0, 0, time.UTC))
+	if !next.Equal(time.Date(2015, 2, 26, 2, 30, 0, 0, time.UTC)) {
+		t.Errorf("next time not match: %s", next)
+	}
+
+	s, _ = Parse("0 0 29 2 *")
+	s.location = time.UTC
+	next = s.Next(now)
+	if !next.Equal(time.Date(2016, 2, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("next time not match: %s", next)
+	}
+
+	// never matched
+	s, _ = Parse("0 0 30 2 *")
+	if next = s.Next(now); !next.IsZero() {
+		t.Errorf("next time must be zero: %s", next)
+	}
+}
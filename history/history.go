@@ -0,0 +1,100 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path"
+	"time"
+
+	"github.com/uchimanajet7/rds-try/logger"
+)
+
+// Entry struct is the Time and Command and Name and Status and Message and Elapsed and Queries variable
+type Entry struct {
+	Time    time.Time     `json:"time"`
+	Command string        `json:"command"`
+	Name    string        `json:"name"` // rds environment name in config file
+	Status  string        `json:"status"`
+	Message string        `json:"message,omitempty"`
+	Elapsed time.Duration `json:"elapsed"`
+	Queries []Query       `json:"queries,omitempty"`
+}
+
+// Query struct is the DBIdentifier and Name and Rows and Checksum of executed query variable
+// two runs returned identical data if the rows and checksum are same
+type Query struct {
+	DBIdentifier string `json:"db_identifier"`
+	Name         string `json:"name"`
+	Rows         int64  `json:"rows"`
+	Checksum     string `json:"checksum"` // sha256 of result set
+}
+
+// status of entry
+const (
+	StatusStarted   = "started"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusTimeout   = "timeout"
+	StatusSkipped   = "skipped"
+)
+
+const historyFile = "rds-try.history"
+
+var log = logger.GetLogger("history")
+
+// GetPath is return history file path in the directory.
+func GetPath(root string) string {
+	return path.Join(root, historyFile)
+}
+
+// Append is the entry is written to the file as one line of JSON.
+// the file is created if not exist.
+func Append(file string, entry *Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return err
+	}
+
+	out, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return err
+	}
+	defer out.Close()
+
+	_, err = out.Write(append(data, '\n'))
+	if err != nil {
+		log.Errorf("%s", err.Error())
+	}
+
+	return err
+}
+
+// Load is the entries are loaded from the file in written order.
+func Load(file string) ([]Entry, error) {
+	in, err := os.Open(file)
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return nil, err
+	}
+	defer in.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			log.Errorf("%s", err.Error())
+			return entries, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
@@ -0,0 +1,79 @@
+package history
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/uchimanajet7/rds-try/utils"
+)
+
+func TestAppendAndLoad(t *testing.T) {
+	tempDir, _ := ioutil.TempDir("", utils.GetAppName()+"-test")
+	defer os.RemoveAll(tempDir)
+
+	file := GetPath(tempDir)
+	entries := []Entry{
+		{
+			Command: "schedule",
+			Name:    "default",
+			Status:  StatusStarted,
+		},
+		{
+			Command: "es",
+			Name:    "default",
+			Status:  StatusFailed,
+			Message: "DB Instance is time out",
+			Elapsed: 30 * time.Minute,
+		},
+		{
+			Command: "es",
+			Name:    "default",
+			Status:  StatusSucceeded,
+			Elapsed: 40 * time.Minute,
+			Queries: []Query{
+				{
+					DBIdentifier: "rds-try-test-db-1",
+					Name:         "q1",
+					Rows:         10,
+					Checksum:     "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+				},
+			},
+		},
+	}
+	for i := range entries {
+		if err := Append(file, &entries[i]); err != nil {
+			t.Errorf("[Append] result error: %s", err.Error())
+		}
+	}
+
+	loaded, err := Load(file)
+	if err != nil {
+		t.Errorf("[Load] result error: %s", err.Error())
+	}
+	if len(loaded) != len(entries) {
+		t.Fatalf("loaded count not match: %d", len(loaded))
+	}
+	for i, entry := range loaded {
+		if entry.Time.IsZero() {
+			t.Errorf("entry time is not set: %+v", entry)
+		}
+		if entry.Command != entries[i].Command || entry.Status != entries[i].Status || entry.Message != entries[i].Message || entry.Elapsed != entries[i].Elapsed {
+			t.Errorf("loaded entry not match: %+v", entry)
+		}
+		if len(entry.Queries) != len(entries[i].Queries) {
+			t.Errorf("loaded queries count not match: %+v", entry)
+			continue
+		}
+		for j, q := range entry.Queries {
+			if q != entries[i].Queries[j] {
+				t.Errorf("loaded query not match: %+v", q)
+			}
+		}
+	}
+
+	if _, err := Load(GetPath(tempDir + "-not-exist")); err == nil {
+		t.Error("loading not exist file must be error")
+	}
+}
@@ -0,0 +1,167 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/uchimanajet7/rds-try/config"
+	"github.com/uchimanajet7/rds-try/logger"
+	"github.com/uchimanajet7/rds-try/utils"
+)
+
+// Event struct is the Command and Status and Name and Message and Resources and Time variable
+type Event struct {
+	Command   string    `json:"command"`
+	Status    string    `json:"status"`
+	Name      string    `json:"name"` // rds environment name in config file
+	Message   string    `json:"message,omitempty"`
+	Resources []string  `json:"resources,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// Notifier struct is the URLs and Format and Retry and RetryInterval and Client variable
+type Notifier struct {
+	URLs          []string
+	Format        string
+	Retry         int
+	RetryInterval time.Duration
+	Client        *http.Client
+}
+
+// status of event
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusTimeout   = "timeout"
+	StatusDeleted   = "deleted"
+)
+
+// format of request body
+const (
+	FormatJSON  = "json"
+	FormatSlack = "slack"
+)
+
+// default values when not specified in config file
+const (
+	defaultRetry         = 3
+	defaultRetryInterval = 5 * time.Second
+	defaultTimeout       = 10 * time.Second
+)
+
+var log = logger.GetLogger("notify")
+
+var (
+	// ErrFormatNotFound is "notify format not found" error.
+	ErrFormatNotFound = errors.New("notify format not found")
+	// ErrWebhookStatus is "webhook returned error status" error.
+	ErrWebhookStatus = errors.New("webhook returned error status")
+)
+
+// NewNotifier is return Notifier of "notify" section.
+// nil is returned when urls is not specified.
+func NewNotifier(conf config.NotifyConfig) *Notifier {
+	if len(conf.URLs) <= 0 {
+		return nil
+	}
+
+	n := &Notifier{
+		URLs:          conf.URLs,
+		Format:        conf.Format,
+		Retry:         conf.Retry,
+		RetryInterval: defaultRetryInterval,
+		Client:        &http.Client{Timeout: defaultTimeout},
+	}
+	if n.Format == "" {
+		n.Format = FormatJSON
+	}
+	if n.Retry <= 0 {
+		n.Retry = defaultRetry
+	}
+
+	return n
+}
+
+// Send is POST the event to all webhook urls.
+// each url is retried up to Retry times, and the last error is returned.
+// nothing is done with nil.
+func (n *Notifier) Send(event *Event) error {
+	if n == nil {
+		return nil
+	}
+
+	body, err := n.getBody(event)
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return err
+	}
+
+	var lastErr error
+	for _, url := range n.URLs {
+		if err := n.post(url, body); err != nil {
+			log.Errorf("failed to notify %s: %s", url, err.Error())
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+// retry until succeeded or Retry times failed
+func (n *Notifier) post(url string, body []byte) error {
+	var err error
+	for i := 0; i < n.Retry; i++ {
+		if i > 0 {
+			time.Sleep(n.RetryInterval)
+		}
+
+		var resp *http.Response
+		resp, err = n.Client.Post(url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			log.Infof("notify try %d of %d: %s", i+1, n.Retry, err.Error())
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			log.Debugf("notified %s: %s", url, resp.Status)
+			return nil
+		}
+		err = ErrWebhookStatus
+		log.Infof("notify try %d of %d: %s %s", i+1, n.Retry, err.Error(), resp.Status)
+	}
+
+	return err
+}
+
+// JSON of event or Slack-compatible message
+func (n *Notifier) getBody(event *Event) ([]byte, error) {
+	switch n.Format {
+	case FormatJSON:
+		return json.Marshal(event)
+	case FormatSlack:
+		return json.Marshal(map[string]string{"text": event.getText()})
+	}
+
+	return nil, ErrFormatNotFound
+}
+
+// ex.
+// rds-try es succeeded [default]
+// - DB Instance: rds-try-test-db-1 in us-west-2
+func (e *Event) getText() string {
+	text := fmt.Sprintf("%s %s %s [%s]", utils.GetAppName(), e.Command, e.Status, e.Name)
+	if e.Message != "" {
+		text += "\n" + e.Message
+	}
+	if len(e.Resources) > 0 {
+		text += "\n- " + strings.Join(e.Resources, "\n- ")
+	}
+
+	return text
+}
@@ -0,0 +1,137 @@
+package notify
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/uchimanajet7/rds-try/config"
+)
+
+func getTestEvent() *Event {
+	return &Event{
+		Command:   "es",
+		Status:    StatusSucceeded,
+		Name:      "default",
+		Message:   "all queries executed",
+		Resources: []string{"DB Instance: rds-try-test-db-1 in us-west-2"},
+		Time:      time.Date(2015, 2, 25, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// return test server that fails until the count of failures, and the received bodies
+func getTestServer(failures int) (*httptest.Server, *[]string) {
+	var mutex sync.Mutex
+	var bodies []string
+	count := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		count++
+		if count <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+	}))
+
+	return ts, &bodies
+}
+
+func TestNewNotifier(t *testing.T) {
+	if n := NewNotifier(config.NotifyConfig{}); n != nil {
+		t.Errorf("notifier must be nil without urls: %+v", n)
+	}
+
+	n := NewNotifier(config.NotifyConfig{URLs: []string{"http://localhost/hooks/test"}})
+	if n.Format != FormatJSON || n.Retry != defaultRetry {
+		t.Errorf("notifier default values not match: %+v", n)
+	}
+
+	// nothing is done with nil
+	var nn *Notifier
+	if err := nn.Send(getTestEvent()); err != nil {
+		t.Errorf("[Send] result error: %s", err.Error())
+	}
+}
+
+func TestSendJSON(t *testing.T) {
+	ts, bodies := getTestServer(0)
+	defer ts.Close()
+
+	n := NewNotifier(config.NotifyConfig{URLs: []string{ts.URL}})
+	if err := n.Send(getTestEvent()); err != nil {
+		t.Errorf("[Send] result error: %s", err.Error())
+	}
+
+	if len(*bodies) != 1 {
+		t.Fatalf("received count not match: %d", len(*bodies))
+	}
+	event := &Event{}
+	if err := json.Unmarshal([]byte((*bodies)[0]), event); err != nil {
+		t.Errorf("received body is not json: %s", err.Error())
+	}
+	if event.Command != "es" || event.Status != StatusSucceeded || len(event.Resources) != 1 {
+		t.Errorf("received event not match: %+v", event)
+	}
+}
+
+func TestSendSlack(t *testing.T) {
+	ts, bodies := getTestServer(0)
+	defer ts.Close()
+
+	n := NewNotifier(config.NotifyConfig{URLs: []string{ts.URL}, Format: FormatSlack})
+	if err := n.Send(getTestEvent()); err != nil {
+		t.Errorf("[Send] result error: %s", err.Error())
+	}
+
+	if len(*bodies) != 1 {
+		t.Fatalf("received count not match: %d", len(*bodies))
+	}
+	message := map[string]string{}
+	json.Unmarshal([]byte((*bodies)[0]), &message)
+	for _, s := range []string{"rds-try es succeeded [default]", "all queries executed", "- DB Instance: rds-try-test-db-1 in us-west-2"} {
+		if !strings.Contains(message["text"], s) {
+			t.Errorf("slack text not contains: %s", s)
+		}
+	}
+}
+
+func TestSendRetry(t *testing.T) {
+	// succeeded at the last try
+	ts, bodies := getTestServer(2)
+	defer ts.Close()
+
+	n := NewNotifier(config.NotifyConfig{URLs: []string{ts.URL}, Retry: 3})
+	n.RetryInterval = time.Millisecond
+	if err := n.Send(getTestEvent()); err != nil {
+		t.Errorf("[Send] result error: %s", err.Error())
+	}
+	if len(*bodies) != 1 {
+		t.Errorf("received count not match: %d", len(*bodies))
+	}
+
+	// failed at all tries
+	fs, _ := getTestServer(3)
+	defer fs.Close()
+
+	n = NewNotifier(config.NotifyConfig{URLs: []string{fs.URL}, Retry: 3})
+	n.RetryInterval = time.Millisecond
+	if err := n.Send(getTestEvent()); err != ErrWebhookStatus {
+		t.Errorf("[Send] error not match: %v", err)
+	}
+
+	// unknown format
+	n.Format = "xml"
+	if err := n.Send(getTestEvent()); err != ErrFormatNotFound {
+		t.Errorf("[Send] error not match: %v", err)
+	}
+}
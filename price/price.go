@@ -0,0 +1,128 @@
+package price
+
+import (
+	"errors"
+	"path"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/uchimanajet7/rds-try/logger"
+	"github.com/uchimanajet7/rds-try/utils"
+)
+
+// Prices struct have Instance array and Storage array variable
+type Prices struct {
+	Instance []InstancePrice
+	Storage  []StoragePrice
+}
+
+// InstancePrice struct have Region and Engine and Type and Hourly variable
+type InstancePrice struct {
+	Region string  `toml:"region"`
+	Engine string  `toml:"engine"`
+	Type   string  `toml:"type"`
+	Hourly float64 `toml:"hourly"` // per hour of Single-AZ
+}
+
+// StoragePrice struct have Region and Type and Monthly and IopsMonthly variable
+type StoragePrice struct {
+	Region      string  `toml:"region"`
+	Type        string  `toml:"type"`
+	Monthly     float64 `toml:"monthly"`      // per GB-month
+	IopsMonthly float64 `toml:"iops_monthly"` // per IOPS-month, only "io1"
+}
+
+// EstimateArgs struct is the DB Instance settings and Lifetime variable
+type EstimateArgs struct {
+	Region           string
+	Engine           string
+	DBInstanceClass  string
+	MultiAZ          bool
+	StorageType      string
+	AllocatedStorage int64
+	Iops             int64
+	Lifetime         time.Duration
+}
+
+const priceFile = "rds-try.price"
+
+// AWS calculates the monthly price by 730 hours
+const hoursPerMonth = 730
+
+var log = logger.GetLogger("price")
+
+var (
+	// ErrPriceNotFound is "price item not found in price file" error.
+	ErrPriceNotFound = errors.New("price item not found in price file")
+	// ErrInstancePriceNotFound is "instance price not found in price file" error.
+	ErrInstancePriceNotFound = errors.New("instance price not found in price file")
+	// ErrStoragePriceNotFound is "storage price not found in price file" error.
+	ErrStoragePriceNotFound = errors.New("storage price not found in price file")
+)
+
+// LoadPrice is the contents are loaded from "rds-try.price" file.
+func LoadPrice(file string) (*Prices, error) {
+	prices := &Prices{}
+
+	if _, err := toml.DecodeFile(file, &prices); err != nil {
+		log.Errorf("%s", err.Error())
+		return prices, err
+	}
+
+	// check require values
+	if len(prices.Instance) <= 0 {
+		log.Errorf("%s", ErrPriceNotFound.Error())
+		return nil, ErrPriceNotFound
+	}
+	log.Debugf("Prices: %+v", prices)
+
+	return prices, nil
+}
+
+// GetDefaultPath is return default price file path.
+func GetDefaultPath() string {
+	return path.Join(utils.GetHomeDir(), priceFile)
+}
+
+// Estimate is return the estimated cost of DB Instance running for Lifetime.
+// Multi-AZ is twice the price of Single-AZ.
+// storage price is not added if storage price of the region is not found.
+func (p *Prices) Estimate(args *EstimateArgs) (float64, error) {
+	var instance *InstancePrice
+	for i, item := range p.Instance {
+		if item.Region == args.Region && item.Engine == args.Engine && item.Type == args.DBInstanceClass {
+			instance = &p.Instance[i]
+			break
+		}
+	}
+	if instance == nil {
+		log.Errorf("%s: %s %s %s", ErrInstancePriceNotFound.Error(), args.Region, args.Engine, args.DBInstanceClass)
+		return 0, ErrInstancePriceNotFound
+	}
+
+	hours := args.Lifetime.Hours()
+	cost := instance.Hourly * hours
+
+	var storage *StoragePrice
+	for i, item := range p.Storage {
+		if item.Region == args.Region && item.Type == args.StorageType {
+			storage = &p.Storage[i]
+			break
+		}
+	}
+	if storage != nil {
+		cost += storage.Monthly * float64(args.AllocatedStorage) * hours / hoursPerMonth
+		if args.StorageType == "io1" {
+			cost += storage.IopsMonthly * float64(args.Iops) * hours / hoursPerMonth
+		}
+	} else {
+		log.Debugf("%s: %s %s", ErrStoragePriceNotFound.Error(), args.Region, args.StorageType)
+	}
+
+	if args.MultiAZ {
+		cost *= 2
+	}
+
+	return cost, nil
+}
@@ -0,0 +1,113 @@
+package price
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/uchimanajet7/rds-try/utils"
+)
+
+func getTestPrices() *Prices {
+	return &Prices{
+		Instance: []InstancePrice{
+			{
+				Region: "ap-northeast-1",
+				Engine: "mysql",
+				Type:   "db.m3.medium",
+				Hourly: 0.125,
+			},
+			{
+				Region: "us-east-1",
+				Engine: "mysql",
+				Type:   "db.m3.medium",
+				Hourly: 0.09,
+			},
+		},
+		Storage: []StoragePrice{
+			{
+				Region:      "ap-northeast-1",
+				Type:        "io1",
+				Monthly:     0.146,
+				IopsMonthly: 0.12,
+			},
+		},
+	}
+}
+
+func TestLoadPrice(t *testing.T) {
+	prices := getTestPrices()
+
+	tempFile, err := ioutil.TempFile("", utils.GetAppName()+"-test")
+	if err != nil {
+		t.Errorf("failed to create the temp file: %s", err.Error())
+	}
+	if err := toml.NewEncoder(tempFile).Encode(prices); err != nil {
+		t.Errorf("failed to create the toml file: %s", err.Error())
+	}
+	tempFile.Sync()
+	tempFile.Close()
+	defer os.Remove(tempFile.Name())
+
+	price, err := LoadPrice(tempFile.Name())
+
+	if err != nil {
+		t.Errorf("price file load error: %s", err.Error())
+	}
+	if !reflect.DeepEqual(prices, price) {
+		t.Errorf("price data not match: %+v/%+v", prices, price)
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	prices := getTestPrices()
+
+	args := &EstimateArgs{
+		Region:           "ap-northeast-1",
+		Engine:           "mysql",
+		DBInstanceClass:  "db.m3.medium",
+		MultiAZ:          true,
+		StorageType:      "io1",
+		AllocatedStorage: 100,
+		Iops:             1000,
+		Lifetime:         2 * time.Hour,
+	}
+	cost, err := prices.Estimate(args)
+	if err != nil {
+		t.Errorf("estimate error: %s", err.Error())
+	}
+	expect := (0.125*2 + 0.146*100*2/730 + 0.12*1000*2/730) * 2
+	if math.Abs(cost-expect) > 0.000001 {
+		t.Errorf("cost not match: %f/%f", cost, expect)
+	}
+
+	// storage price is not found
+	args.Region = "us-east-1"
+	args.MultiAZ = false
+	cost, err = prices.Estimate(args)
+	if err != nil {
+		t.Errorf("estimate error: %s", err.Error())
+	}
+	if math.Abs(cost-0.18) > 0.000001 {
+		t.Errorf("cost not match: %f/%f", cost, 0.18)
+	}
+
+	// instance price is not found
+	args.DBInstanceClass = "db.r3.large"
+	_, err = prices.Estimate(args)
+	if err != ErrInstancePriceNotFound {
+		t.Errorf("error not match: %v", err)
+	}
+}
+
+func TestGetDefaultPath(t *testing.T) {
+	if path.Join(utils.GetHomeDir(), priceFile) != GetDefaultPath() {
+		t.Error("default path not match")
+	}
+}
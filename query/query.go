@@ -1,53 +1,92 @@
-package query
-
-import (
-	"errors"
-	"path"
-
-	"github.com/BurntSushi/toml"
-
-	"github.com/uchimanajet7/rds-try/logger"
-	"github.com/uchimanajet7/rds-try/utils"
-)
-
-// Queries struct have Query array variable
-type Queries struct {
-	Query []Query
-}
-
-// Query struct have Name and Sql variable
-type Query struct {
-	Name string `toml:"name"`
-	SQL  string `toml:"sql"`
-}
-
-const queryFile = "rds-try.query"
-
-var log = logger.GetLogger("query")
-
-// ErrQueryNotFound is "SQL item not found in query file" error.
-var ErrQueryNotFound = errors.New("SQL item not found in query file")
-
-// LoadQuery is the contents are loaded from "rds-try.query" file.
-func LoadQuery(file string) (*Queries, error) {
-	queries := &Queries{}
-
-	if _, err := toml.DecodeFile(file, &queries); err != nil {
-		log.Errorf("%s", err.Error())
-		return queries, err
-	}
-
-	// check require values
-	if len(queries.Query) <= 0 {
-		log.Errorf("%s", ErrQueryNotFound.Error())
-		return nil, ErrQueryNotFound
-	}
-	log.Debugf("Queries: %+v", queries)
-
-	return queries, nil
-}
-
-// GetDefaultPath is return default query file path.
-func GetDefaultPath() string {
-	return path.Join(utils.GetHomeDir(), queryFile)
-}
+package query
+
+import (
+	"errors"
+	"path"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/uchimanajet7/rds-try/logger"
+	"github.com/uchimanajet7/rds-try/utils"
+)
+
+// Queries struct have Query array and Mask array variable
+type Queries struct {
+	Query []Query
+	Mask  []Mask
+}
+
+// Query struct have Name and Sql variable
+type Query struct {
+	Name string `toml:"name"`
+	SQL  string `toml:"sql"`
+}
+
+// Mask struct have Table and Column and Strategy and Value and Length variable
+type Mask struct {
+	Table    string `toml:"table"`
+	Column   string `toml:"column"`
+	Strategy string `toml:"strategy"` // "null", "fixed", "hash", "email" or "truncate"
+	Value    string `toml:"value"`    // the value of "fixed"
+	Length   int    `toml:"length"`   // the length of "truncate"
+}
+
+// Migrations struct have Migration array variable
+type Migrations struct {
+	Migration []Query
+}
+
+const queryFile = "rds-try.query"
+
+var log = logger.GetLogger("query")
+
+var (
+	// ErrQueryNotFound is "SQL item not found in query file" error.
+	ErrQueryNotFound = errors.New("SQL item not found in query file")
+	// ErrMigrationNotFound is "SQL item not found in migration file" error.
+	ErrMigrationNotFound = errors.New("SQL item not found in migration file")
+)
+
+// LoadQuery is the contents are loaded from "rds-try.query" file.
+func LoadQuery(file string) (*Queries, error) {
+	queries := &Queries{}
+
+	if _, err := toml.DecodeFile(file, &queries); err != nil {
+		log.Errorf("%s", err.Error())
+		return queries, err
+	}
+
+	// check require values
+	if len(queries.Query) <= 0 {
+		log.Errorf("%s", ErrQueryNotFound.Error())
+		return nil, ErrQueryNotFound
+	}
+	log.Debugf("Queries: %+v", queries)
+
+	return queries, nil
+}
+
+// LoadMigration is the contents are loaded from migration file.
+// the format is same as query file, but the table name is "migration".
+func LoadMigration(file string) (*Migrations, error) {
+	migrations := &Migrations{}
+
+	if _, err := toml.DecodeFile(file, &migrations); err != nil {
+		log.Errorf("%s", err.Error())
+		return migrations, err
+	}
+
+	// check require values
+	if len(migrations.Migration) <= 0 {
+		log.Errorf("%s", ErrMigrationNotFound.Error())
+		return nil, ErrMigrationNotFound
+	}
+	log.Debugf("Migrations: %+v", migrations)
+
+	return migrations, nil
+}
+
+// GetDefaultPath is return default query file path.
+func GetDefaultPath() string {
+	return path.Join(utils.GetHomeDir(), queryFile)
+}
@@ -1,52 +1,104 @@
-package query
-
-import (
-	"fmt"
-	"io/ioutil"
-	"os"
-	"path"
-	"reflect"
-	"testing"
-
-	"github.com/BurntSushi/toml"
-
-	"github.com/uchimanajet7/rds-try/utils"
-)
-
-func TestLoadQuery(t *testing.T) {
-	queries := &Queries{}
-
-	for i := 0; i < 10; i++ {
-		query := Query{
-			Name: fmt.Sprintf("name_%d", i+1),
-			SQL:  fmt.Sprintf("sql_%d", i+1),
-		}
-		queries.Query = append(queries.Query, query)
-	}
-
-	tempFile, err := ioutil.TempFile("", utils.GetAppName()+"-test")
-	if err != nil {
-		t.Errorf("failed to create the temp file: %s", err.Error())
-	}
-	if err := toml.NewEncoder(tempFile).Encode(queries); err != nil {
-		t.Errorf("failed to create the toml file: %s", err.Error())
-	}
-	tempFile.Sync()
-	tempFile.Close()
-	defer os.Remove(tempFile.Name())
-
-	query, err := LoadQuery(tempFile.Name())
-
-	if err != nil {
-		t.Errorf("query file load error: %s", err.Error())
-	}
-	if !reflect.DeepEqual(queries, query) {
-		t.Errorf("query data not match: %+v/%+v", queries, query)
-	}
-}
-
-func TestGetDefaultPath(t *testing.T) {
-	if path.Join(utils.GetHomeDir(), queryFile) != GetDefaultPath() {
-		t.Error("default path not match")
-	}
-}
+package query
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/uchimanajet7/rds-try/utils"
+)
+
+func TestLoadQuery(t *testing.T) {
+	queries := &Queries{}
+
+	for i := 0; i < 10; i++ {
+		query := Query{
+			Name: fmt.Sprintf("name_%d", i+1),
+			SQL:  fmt.Sprintf("sql_%d", i+1),
+		}
+		queries.Query = append(queries.Query, query)
+	}
+	queries.Mask = []Mask{
+		{
+			Table:    "account",
+			Column:   "name",
+			Strategy: "fixed",
+			Value:    "masked",
+		},
+		{
+			Table:    "account",
+			Column:   "tel",
+			Strategy: "truncate",
+			Length:   3,
+		},
+	}
+
+	tempFile, err := ioutil.TempFile("", utils.GetAppName()+"-test")
+	if err != nil {
+		t.Errorf("failed to create the temp file: %s", err.Error())
+	}
+	if err := toml.NewEncoder(tempFile).Encode(queries); err != nil {
+		t.Errorf("failed to create the toml file: %s", err.Error())
+	}
+	tempFile.Sync()
+	tempFile.Close()
+	defer os.Remove(tempFile.Name())
+
+	query, err := LoadQuery(tempFile.Name())
+
+	if err != nil {
+		t.Errorf("query file load error: %s", err.Error())
+	}
+	if !reflect.DeepEqual(queries, query) {
+		t.Errorf("query data not match: %+v/%+v", queries, query)
+	}
+}
+
+func TestLoadMigration(t *testing.T) {
+	migrations := &Migrations{}
+
+	for i := 0; i < 10; i++ {
+		query := Query{
+			Name: fmt.Sprintf("name_%d", i+1),
+			SQL:  fmt.Sprintf("sql_%d", i+1),
+		}
+		migrations.Migration = append(migrations.Migration, query)
+	}
+
+	tempFile, err := ioutil.TempFile("", utils.GetAppName()+"-test")
+	if err != nil {
+		t.Errorf("failed to create the temp file: %s", err.Error())
+	}
+	if err := toml.NewEncoder(tempFile).Encode(migrations); err != nil {
+		t.Errorf("failed to create the toml file: %s", err.Error())
+	}
+	tempFile.Sync()
+	tempFile.Close()
+	defer os.Remove(tempFile.Name())
+
+	migration, err := LoadMigration(tempFile.Name())
+
+	if err != nil {
+		t.Errorf("migration file load error: %s", err.Error())
+	}
+	if !reflect.DeepEqual(migrations, migration) {
+		t.Errorf("migration data not match: %+v/%+v", migrations, migration)
+	}
+
+	// file does not exist
+	_, err = LoadMigration(tempFile.Name() + "-not-exist")
+	if err == nil {
+		t.Error("migration file not exist but loaded")
+	}
+}
+
+func TestGetDefaultPath(t *testing.T) {
+	if path.Join(utils.GetHomeDir(), queryFile) != GetDefaultPath() {
+		t.Error("default path not match")
+	}
+}
@@ -1,71 +1,115 @@
-package utils
-
-import (
-	"fmt"
-	"strings"
-	"testing"
-)
-
-func TestGetHomeDir(t *testing.T) {
-	if GetHomeDir() == "" {
-		t.Error("user home dir not found")
-	}
-}
-
-func TestGetUserName(t *testing.T) {
-	if GetUserName() == "" {
-		t.Error("user name not found")
-	}
-}
-
-func TestGetAppName(t *testing.T) {
-	if appName != GetAppName() {
-		t.Error("app name not match")
-	}
-}
-
-func TestGetFormatedTime(t *testing.T) {
-	// check format "2015-01-20-18-03-35"
-	strs := strings.Split(GetFormatedTime(), "-")
-
-	if len(strs) != 6 {
-		t.Errorf("time format not match : len(%d)", len(strs))
-	}
-}
-
-func TestGetPrefix(t *testing.T) {
-	if fmt.Sprintf("%s-v", GetAppName()) != GetPrefix() {
-		t.Error("prefix not match")
-	}
-}
-
-func TestGetFormatedAppName(t *testing.T) {
-	// check format "rds-try-v0-0-1"
-	strs := strings.Split(GetFormatedAppName(), "-")
-
-	if len(strs) != 5 {
-		t.Errorf("app name format not match : len(%d)", len(strs))
-	}
-}
-
-func TestGetFormatedDBDisplayName(t *testing.T) {
-	// check format "rds-try-v0-0-1-2015-01-20-18-03-35-dbIdentifier"
-	id := "gotest"
-	strs := strings.Split(GetFormatedDBDisplayName(id), "-")
-
-	if len(strs) != 12 {
-		t.Errorf("db display name format not match : len(%d)", len(strs))
-	}
-	if id != strs[len(strs)-1] {
-		t.Errorf("db id not match : id(%s)", strs[len(strs)-1])
-	}
-}
-
-func TestGetFormatedFileDisplayName(t *testing.T) {
-	// check format "rds-try-2015-01-20"
-	strs := strings.Split(GetFormatedFileDisplayName(), "-")
-
-	if len(strs) != 5 {
-		t.Errorf("file display name format not match : len(%d)", len(strs))
-	}
-}
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetHomeDir(t *testing.T) {
+	if GetHomeDir() == "" {
+		t.Error("user home dir not found")
+	}
+}
+
+func TestGetUserName(t *testing.T) {
+	if GetUserName() == "" {
+		t.Error("user name not found")
+	}
+}
+
+func TestGetAppName(t *testing.T) {
+	if appName != GetAppName() {
+		t.Error("app name not match")
+	}
+}
+
+func TestGetFormatedTime(t *testing.T) {
+	// check format "2015-01-20-18-03-35"
+	strs := strings.Split(GetFormatedTime(), "-")
+
+	if len(strs) != 6 {
+		t.Errorf("time format not match : len(%d)", len(strs))
+	}
+}
+
+func TestParseFormatedTime(t *testing.T) {
+	now := GetFormatedTime()
+	parsed, err := ParseFormatedTime(now)
+
+	if err != nil {
+		t.Errorf("time parse error: %s", err.Error())
+	}
+	if parsed.Format("2006-01-02-15-04-05") != now {
+		t.Errorf("time not match: %s/%s", parsed.Format("2006-01-02-15-04-05"), now)
+	}
+}
+
+func TestParseTagTime(t *testing.T) {
+	// written in Tokyo, and parsed in New York
+	tokyo := time.FixedZone("JST", 9*60*60)
+	written := time.Date(2015, 1, 20, 18, 3, 35, 0, tokyo)
+	value := FormatUTCTime(written)
+	if value != "2015-01-20T09:03:35Z" {
+		t.Errorf("time format not match: %s", value)
+	}
+
+	local := time.Local
+	time.Local = time.FixedZone("EST", -5*60*60)
+	defer func() { time.Local = local }()
+
+	parsed, err := ParseTagTime(value)
+	if err != nil {
+		t.Errorf("time parse error: %s", err.Error())
+	}
+	if !parsed.Equal(written) {
+		t.Errorf("time not match: %s/%s", parsed, written)
+	}
+
+	// the edited date time is local time
+	parsed, err = ParseTagTime("2015-01-20-18-03-35")
+	if err != nil {
+		t.Errorf("time parse error: %s", err.Error())
+	}
+	if !parsed.Equal(time.Date(2015, 1, 20, 18, 3, 35, 0, time.Local)) {
+		t.Errorf("time not match: %s", parsed)
+	}
+}
+
+func TestGetPrefix(t *testing.T) {
+	if fmt.Sprintf("%s-v", GetAppName()) != GetPrefix() {
+		t.Error("prefix not match")
+	}
+}
+
+func TestGetFormatedAppName(t *testing.T) {
+	// check format "rds-try-v0-0-1"
+	strs := strings.Split(GetFormatedAppName(), "-")
+
+	if len(strs) != 5 {
+		t.Errorf("app name format not match : len(%d)", len(strs))
+	}
+}
+
+func TestGetFormatedDBDisplayName(t *testing.T) {
+	// check format "rds-try-v0-0-1-2015-01-20-18-03-35-dbIdentifier"
+	id := "gotest"
+	strs := strings.Split(GetFormatedDBDisplayName(id), "-")
+
+	if len(strs) != 12 {
+		t.Errorf("db display name format not match : len(%d)", len(strs))
+	}
+	if id != strs[len(strs)-1] {
+		t.Errorf("db id not match : id(%s)", strs[len(strs)-1])
+	}
+}
+
+func TestGetFormatedFileDisplayName(t *testing.T) {
+	// check format "rds-try-2015-01-20"
+	strs := strings.Split(GetFormatedFileDisplayName(), "-")
+
+	if len(strs) != 5 {
+		t.Errorf("file display name format not match : len(%d)", len(strs))
+	}
+}